@@ -0,0 +1,210 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+const (
+	// bottleneckBadgeMin is how many waiting issues it takes before a panel
+	// row gets a bottleneck badge
+	bottleneckBadgeMin = 2
+	// reportBottlenecks is how many bottlenecks the report lists
+	reportBottlenecks = 10
+)
+
+// isResolved reports whether an issue no longer blocks anything. Issues that
+// are not loaded are treated as unresolved.
+func (m *Model) isResolved(id string) bool {
+	t, ok := m.tasksMap[id]
+	return ok && t.Status == "closed"
+}
+
+// analyzeDependencies computes the per-issue numbers shown as panel badges:
+// how many unresolved issues wait on each open issue, and which issues are
+// part of a dependency cycle
+func (m *Model) analyzeDependencies() {
+	m.unblockCounts = make(map[string]int)
+	for _, t := range m.tasks {
+		if t.Status == "closed" || len(m.depGraph.Dependents(t.ID)) == 0 {
+			continue
+		}
+		if n := m.depGraph.Unblocks(t.ID, m.isResolved); n > 0 {
+			m.unblockCounts[t.ID] = n
+		}
+	}
+
+	m.cycles = m.depGraph.Cycles()
+	m.cycleIDs = make(map[string]bool)
+	for _, cycle := range m.cycles {
+		for _, id := range cycle {
+			m.cycleIDs[id] = true
+		}
+	}
+}
+
+// openReport shows the blocker analysis for the given issue
+func (m *Model) openReport(task *models.Task) {
+	m.reportID = task.ID
+	m.refreshReport()
+	m.reportViewport.GotoTop()
+	m.mode = ViewReport
+}
+
+// refreshReport rebuilds the report content from the loaded tasks
+func (m *Model) refreshReport() {
+	m.reportViewport.SetContent(m.renderReport())
+}
+
+// reportLine renders one issue of the report
+func (m *Model) reportLine(id string) string {
+	t, ok := m.tasksMap[id]
+	if !ok {
+		return ui.HelpDescStyle.Render(id + " (not loaded)")
+	}
+	priority := ui.PriorityStyle(t.Priority).Render(t.PriorityString())
+	idStyled := ui.HelpDescStyle.Render(t.ID)
+	status := ui.StatusStyle(t.Status).Render("[" + t.Status + "]")
+	return fmt.Sprintf("%s %s %s %s", priority, idStyled, t.Title, status)
+}
+
+func (m *Model) renderReport() string {
+	var b strings.Builder
+
+	root, ok := m.tasksMap[m.reportID]
+	if !ok {
+		return ui.HelpDescStyle.Render(m.reportID + " is no longer loaded")
+	}
+
+	// For an epic the chain may end at any of its open descendants
+	targets := []string{root.ID}
+	for _, t := range m.tasks {
+		if t.Status != "closed" && m.isDescendantOf(t.ID, root.ID) {
+			targets = append(targets, t.ID)
+		}
+	}
+	scope := "issue"
+	if len(targets) > 1 {
+		scope = fmt.Sprintf("issue and %d open descendants", len(targets)-1)
+	}
+
+	b.WriteString(ui.FormLabelStyle.Render("Critical path"))
+	b.WriteString(ui.HelpDescStyle.Render("  longest chain of unresolved blockers (" + scope + ")"))
+	b.WriteString("\n\n")
+	chain := m.depGraph.BlockerChain(targets, m.isResolved)
+	if len(chain) <= 1 {
+		b.WriteString("  No unresolved blockers.\n")
+	} else {
+		b.WriteString(fmt.Sprintf("  %d issues deep; start with %s\n\n", len(chain), chain[0]))
+		for i, id := range chain {
+			arrow := "  "
+			if i > 0 {
+				arrow = "→ "
+			}
+			b.WriteString(fmt.Sprintf("  %2d. %s%s\n", i+1, arrow, m.reportLine(id)))
+		}
+	}
+
+	// Bottlenecks: open issues with the most work waiting on them
+	type bottleneck struct {
+		id    string
+		count int
+	}
+	var bottlenecks []bottleneck
+	for id, count := range m.unblockCounts {
+		bottlenecks = append(bottlenecks, bottleneck{id, count})
+	}
+	sort.Slice(bottlenecks, func(i, j int) bool {
+		if bottlenecks[i].count != bottlenecks[j].count {
+			return bottlenecks[i].count > bottlenecks[j].count
+		}
+		return bottlenecks[i].id < bottlenecks[j].id
+	})
+	if len(bottlenecks) > reportBottlenecks {
+		bottlenecks = bottlenecks[:reportBottlenecks]
+	}
+
+	b.WriteString("\n")
+	b.WriteString(ui.FormLabelStyle.Render("Bottlenecks"))
+	b.WriteString(ui.HelpDescStyle.Render("  closing these unblocks the most open work"))
+	b.WriteString("\n\n")
+	if len(bottlenecks) == 0 {
+		b.WriteString("  No open issue is blocking other work.\n")
+	}
+	onChain := make(map[string]bool)
+	for _, id := range chain {
+		onChain[id] = true
+	}
+	for _, bn := range bottlenecks {
+		mark := " "
+		if onChain[bn.id] {
+			mark = "*"
+		}
+		b.WriteString(fmt.Sprintf("  %s %3d waiting  %s\n", mark, bn.count, m.reportLine(bn.id)))
+	}
+	if len(chain) > 1 && len(bottlenecks) > 0 {
+		b.WriteString(ui.HelpDescStyle.Render("\n  * on the critical path above\n"))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(ui.FormLabelStyle.Render("Dependency cycles"))
+	b.WriteString("\n\n")
+	if len(m.cycles) == 0 {
+		b.WriteString("  None found.\n")
+	}
+	for i, cycle := range m.cycles {
+		b.WriteString(fmt.Sprintf("  %d. %s\n", i+1, strings.Join(cycle, " ⇄ ")))
+		for _, id := range cycle {
+			b.WriteString("       " + m.reportLine(id) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func (m *Model) handleReportKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.List.Cancel), key.Matches(msg, m.keys.List.Report):
+		m.mode = ViewList
+	case key.Matches(msg, m.keys.List.Up):
+		m.reportViewport.LineUp(1)
+	case key.Matches(msg, m.keys.List.Down):
+		m.reportViewport.LineDown(1)
+	case key.Matches(msg, m.keys.List.PageUp):
+		m.reportViewport.HalfViewUp()
+	case key.Matches(msg, m.keys.List.PageDown):
+		m.reportViewport.HalfViewDown()
+	case key.Matches(msg, m.keys.List.Top):
+		m.reportViewport.GotoTop()
+	case key.Matches(msg, m.keys.List.Bottom):
+		m.reportViewport.GotoBottom()
+	case key.Matches(msg, m.keys.List.Graph):
+		if t, ok := m.tasksMap[m.reportID]; ok {
+			m.openGraph(t)
+		}
+	}
+	return nil
+}
+
+func (m Model) viewReport() string {
+	var b strings.Builder
+
+	b.WriteString(ui.TitleStyle.Render("Blocker Analysis: "+m.reportID) + "\n\n")
+	b.WriteString(ui.OverlayStyle.
+		Width(m.width - 4).
+		Height(m.reportViewport.Height).
+		Render(m.reportViewport.View()))
+	b.WriteString("\n")
+
+	scrollInfo := fmt.Sprintf("%d%%", int(m.reportViewport.ScrollPercent()*100))
+	b.WriteString(ui.HelpBarStyle.Render("j/k:scroll  ^u/^d:page  M:graph  P/esc:close  " + scrollInfo))
+
+	return b.String()
+}
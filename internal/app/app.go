@@ -1,12 +1,14 @@
 package app
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -36,6 +38,19 @@ const (
 	ViewAddBlocker
 	ViewRemoveBlocker
 	ViewEditText
+	ViewMoveParent
+	ViewPickTemplate
+	ViewEditLabels
+	ViewEditAssignee
+	ViewEditDate
+	ViewGraph
+	ViewReport
+	ViewPickDepType
+	ViewTimeline
+	ViewCalendar
+	ViewSnooze
+	ViewCommandOutput
+	ViewCommandPrompt
 )
 
 // PanelFocus represents which panel is focused
@@ -52,10 +67,13 @@ const (
 type FilterMode int
 
 const (
-	FilterAll    FilterMode = iota // Show all tasks
-	FilterOpen                     // Show only open tasks
-	FilterClosed                   // Show only closed tasks
-	FilterReady                    // Show only ready tasks (no blockers)
+	FilterAll      FilterMode = iota // Show all tasks
+	FilterOpen                       // Show only open tasks
+	FilterClosed                     // Show only closed tasks
+	FilterReady                      // Show only ready tasks (no blockers)
+	FilterDeferred                   // Show only tasks deferred to a later day
+	FilterDue                        // Show only overdue and due-soon tasks
+	filterModeCount
 )
 
 // String returns the display name for the filter mode
@@ -69,6 +87,10 @@ func (f FilterMode) String() string {
 		return "Closed"
 	case FilterReady:
 		return "Ready"
+	case FilterDeferred:
+		return "Deferred"
+	case FilterDue:
+		return "Due"
 	default:
 		return "?"
 	}
@@ -107,9 +129,14 @@ func (s SortMode) String() string {
 // taskItem wraps a Task for the list component with tree metadata
 type taskItem struct {
 	task        models.Task
-	depth       int  // 0=root, 1=child, 2=grandchild
-	hasChildren bool // has visible children in this panel
-	expanded    bool // current expanded state (true = children shown)
+	depth       int           // 0=root, 1=child, 2=grandchild
+	hasChildren bool          // has visible children in this panel
+	expanded    bool          // current expanded state (true = children shown)
+	marked      bool          // part of the multi-selection
+	unblocks    int           // open issues waiting on this one (directly or transitively)
+	inCycle     bool          // part of a dependency cycle
+	rollup      models.Rollup // progress of all descendants (zero if none)
+	due         models.DueStatus
 }
 
 func (t taskItem) Title() string {
@@ -127,7 +154,7 @@ func (t taskItem) FilterValue() string {
 // Model is the main application state
 type Model struct {
 	client *beads.Client
-	keys   ui.KeyMap
+	keys   ui.KeyMaps
 	help   help.Model
 
 	// Data
@@ -154,10 +181,13 @@ type Model struct {
 
 	// Form state
 	formTitle    textinput.Model
-	formDesc     textinput.Model
+	formDesc     textarea.Model
 	formPriority int
 	formType     string
 	formFocus    int
+	formParentID string // parent issue ID when creating a child task
+	formLabels   []string
+	formTemplate *config.IssueTemplate // template the form was started from, if any
 	editing      bool
 	editingID    string
 
@@ -166,8 +196,9 @@ type Model struct {
 	confirmAction func() tea.Cmd
 
 	// Modal state for field editing
-	modal     ui.Modal
-	editField string // tracks which field is being edited ("description" or "notes")
+	modal      ui.Modal
+	editField  string          // tracks which field is being edited ("description", "notes", "due", "defer" or "snooze")
+	labelDraft map[string]bool // labels of the task in the label editor
 
 	// Filter state
 	filterQuery string
@@ -193,22 +224,94 @@ type Model struct {
 	// Status message (flash notification)
 	statusMsg string
 
+	// Config problems shown in a banner until dismissed
+	configProblems []config.Problem
+	configStamp    string // config files' sizes and mtimes, to detect edits
+
 	// Task lookup map for O(1) access by ID (used for linked issue display)
 	tasksMap map[string]*models.Task
 
+	// Blocking relationships between loaded tasks
+	depGraph *models.DepGraph
+
+	// Dependency graph view state
+	graphRootID  string // issue the graph is centered on
+	graphFocusID string // node under the cursor
+	graphDepth   int    // levels shown in each direction
+
+	// Blocker analysis (report view and panel badges)
+	reportID       string          // issue the report was opened for
+	reportViewport viewport.Model  // scrollable report content
+	unblockCounts  map[string]int  // open issues waiting on each issue
+	cycles         [][]string      // groups of issues blocking each other
+	cycleIDs       map[string]bool // members of any cycle
+
+	// Custom command waiting for answers to its prompts
+	promptRun *commandPromptRun
+
+	// Captured output of the last custom command run in capture mode
+	outputTitle    string
+	outputReturn   ViewMode       // view to return to when the output is closed
+	outputViewport viewport.Model // scrollable command output
+
+	// Progress of each issue's descendants, keyed by parent ID
+	rollups map[string]models.Rollup
+
+	// Timeline view state
+	timelineZoom  timelineZoom
+	timelineStart time.Time // date of the first visible column
+	timelineRow   int       // index of the selected issue among timeline rows
+
+	// Calendar view state
+	calendarCursor    time.Time // selected day
+	calendarWeekly    bool      // show one week instead of a month
+	calendarEntry     int       // selected issue of the day (or of the tray)
+	calendarTray      bool      // overdue tray has focus
+	calendarMoveID    string    // issue being moved to another day
+	calendarMoveDefer bool      // moving the defer date rather than the due date
+
+	// Deferred issues
+	deferredCount int             // deferred issues hidden from the panels
+	sleepingIDs   map[string]bool // issues deferred to a later day at the last load
+	snoozeIDs     []string        // issues the snooze menu applies to
+
 	// Comments for selected task
 	comments     []models.Comment
 	commentInput textinput.Model
 
 	// Blocker selection (for add/remove blocker modals)
-	blockerOptions []string // List of issue IDs to choose from
+	blockerOptions []string      // List of issue IDs to choose from
+	depOption      depTypeOption // relationship being added
+
+	// Linked issue focused in the detail view (tab cycles, enter opens)
+	detailLinkID   string
+	detailLinkLine int      // content line of the focused link, for scrolling
+	detailBack     []string // issues to return to with HistoryBack
+	detailForward  []string // issues left with HistoryBack
+
+	// Due date highlighting thresholds from config, in days
+	dueSoonDays  int
+	dueGraceDays int
+	overdueCount int // overdue issues among the loaded tasks
+	dueSoonCount int // issues due within dueSoonDays
 
 	// Custom commands from config
 	customCommands []config.CustomCommand
 
+	// Issue templates from config (offered when creating issues)
+	templates        []config.IssueTemplate
+	templateRun      *templateRun // in-flight multi-issue template creation
+	templateParentID string       // parent the template's root issue was created under
+
 	// Tree view state: tracks which nodes are collapsed.
 	// Default is expanded (absent = expanded, present+true = collapsed).
 	collapsedNodes map[string]bool
+
+	// Multi-selection: IDs of marked tasks (bulk actions apply to these)
+	marked map[string]bool
+
+	// Task to select once it shows up after a reload (e.g. a newly created child)
+	pendingSelectID string
 }
 
 // New creates a new application model
@@ -247,10 +350,11 @@ func New() Model {
 	formTitle.Placeholder = "Enter a brief, descriptive title for this task"
 	formTitle.CharLimit = 200
 
-	formDesc := textinput.New()
-	formDesc.Prompt = ""
+	formDesc := textarea.New()
 	formDesc.Placeholder = "Add details, context, or acceptance criteria (optional)"
-	formDesc.CharLimit = 1000
+	formDesc.ShowLineNumbers = false
+	formDesc.CharLimit = 0
+	formDesc.SetHeight(formDescHeight)
 
 	// Initialize comment input
 	commentInput := textinput.New()
@@ -258,20 +362,16 @@ func New() Model {
 	commentInput.Placeholder = "Enter your comment..."
 	commentInput.CharLimit = 1000
 
-	// Load config (ignore errors, use empty config)
+	// Load config (ignore errors, use empty config). The stamp is taken
+	// first so edits made while loading are picked up by the next check.
+	stamp := configStamp()
 	cfg, _ := config.Load()
-	var customCmds []config.CustomCommand
-	if cfg != nil {
-		customCmds = cfg.CustomCommands
+	if cfg == nil {
+		cfg = &config.Config{}
 	}
 
-	// Build key map with custom commands
-	keys := ui.DefaultKeyMap()
-	keys.CustomCommands = buildCustomCommandBindings(customCmds)
-
-	return Model{
+	m := Model{
 		client:          beads.NewClient(),
-		keys:            keys,
 		help:            h,
 		mode:            ViewList,
 		focusedPanel:    FocusInProgress,
@@ -287,9 +387,15 @@ func New() Model {
 		formPriority:    2,
 		formType:        "feature",
 		commentInput:    commentInput,
-		customCommands:  customCmds,
-		collapsedNodes: make(map[string]bool),
+		collapsedNodes:  make(map[string]bool),
+		marked:          make(map[string]bool),
+		configStamp:     stamp,
 	}
+
+	// Theme, key maps with custom commands, templates and due thresholds.
+	// Invalid settings fall back to the defaults and are reported in a banner.
+	m.applyConfig(cfg)
+	return m
 }
 
 // buildCustomCommandBindings creates key bindings from custom commands
@@ -329,15 +435,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		// Global key handling - intercept before components
-		switch msg.String() {
-		case "ctrl+c":
+		keys := m.activeKeys()
+		switch {
+		case msg.String() == "ctrl+c":
 			return m, tea.Quit
-		case "q":
+		case key.Matches(msg, keys.Quit) &&
+			(m.mode == ViewList || m.mode == ViewBoard || m.mode == ViewGraph || m.mode == ViewTimeline || m.mode == ViewCalendar):
 			// Quit from list or board view
-			if m.mode == ViewList || m.mode == ViewBoard {
-				return m, tea.Quit
-			}
-		case "esc":
+			return m, tea.Quit
+		case key.Matches(msg, keys.Cancel):
 			// If in search mode, exit search mode and clear filter
 			if m.searchMode {
 				m.searchMode = false
@@ -350,13 +456,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Handle escape based on current mode
 			switch m.mode {
 			case ViewDetail:
-				// Return to where we came from (board or list)
-				if m.previousMode == ViewBoard {
-					m.mode = ViewBoard
+				m.closeDetail()
+				return m, nil
+			case ViewCommandOutput:
+				m.mode = m.outputReturn
+				return m, nil
+			case ViewCommandPrompt:
+				m.cancelPrompts()
+				return m, nil
+			case ViewCalendar:
+				// Cancel a move in progress before leaving the calendar
+				if m.calendarMoveID != "" {
+					m.calendarMoveID = ""
 				} else {
 					m.mode = ViewList
 				}
-				m.previousMode = ViewList // Reset
 				return m, nil
 			case ViewList:
 				// In list mode, clear filter if active
@@ -365,6 +479,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.distributeTasks()
 					return m, nil
 				}
+				// Otherwise dismiss the config problems banner
+				if len(m.configProblems) > 0 {
+					m.configProblems = nil
+					m.updateSizes()
+				}
 				return m, nil
 			default:
 				// Other modes: go back to list
@@ -392,7 +511,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = nil
 			m.tasks = msg.tasks
 			m.readyIDs = msg.readyIDs
+			m.indexTasks()
 			m.distributeTasks()
+			if m.pendingSelectID != "" && m.revealTask(m.pendingSelectID) {
+				m.pendingSelectID = ""
+			}
+			if cmd := m.checkWakeups(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 
 	case taskCreatedMsg:
@@ -400,15 +526,78 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		} else {
 			m.mode = ViewList
+			if msg.parentID != "" {
+				// Expand the parent so the new child is visible, then select it
+				delete(m.collapsedNodes, msg.parentID)
+			}
+			if msg.task != nil {
+				m.pendingSelectID = msg.task.ID
+			}
 			if !m.loading {
 				m.loading = true
 				cmds = append(cmds, m.loadTasks())
 			}
 		}
 
+	case templateStepMsg:
+		run := m.templateRun
+		if run == nil {
+			break
+		}
+		if msg.err != nil {
+			run.failure = fmt.Errorf("template %q failed at step %d/%d: %w", run.name, run.done+1, len(run.steps), msg.err)
+			m.err = run.failure
+			if len(run.created) > 0 {
+				cmds = append(cmds, m.rollbackTemplateRun(run))
+			} else {
+				m.templateRun = nil
+			}
+			break
+		}
+		run.done++
+		if run.done < len(run.steps) {
+			cmds = append(cmds, m.runTemplateStep(run))
+			break
+		}
+		// All steps done: expand and select the new issue
+		m.templateRun = nil
+		if m.templateParentID != "" {
+			delete(m.collapsedNodes, m.templateParentID)
+		}
+		rootID := run.rootID()
+		delete(m.collapsedNodes, rootID)
+		m.pendingSelectID = rootID
+		m.statusMsg = fmt.Sprintf("Created %d issue(s) from template %q", len(run.created), run.name)
+		cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		}))
+		if !m.loading {
+			m.loading = true
+			cmds = append(cmds, m.loadTasks())
+		}
+
+	case templateRollbackMsg:
+		if run := m.templateRun; run != nil {
+			if msg.err != nil {
+				m.err = fmt.Errorf("%v; rollback deleted %d issue(s) but failed: %w", run.failure, msg.deleted, msg.err)
+			} else {
+				m.err = fmt.Errorf("%v; rolled back %d created issue(s)", run.failure, msg.deleted)
+			}
+		}
+		m.templateRun = nil
+		if !m.loading {
+			m.loading = true
+			cmds = append(cmds, m.loadTasks())
+		}
+
 	case taskUpdatedMsg:
 		if msg.err != nil {
 			m.err = msg.err
+		} else if msg.status != "" {
+			m.statusMsg = msg.status
+			cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+				return clearStatusMsg{}
+			}))
 		}
 		if !m.loading {
 			m.loading = true
@@ -425,6 +614,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, m.loadTasks())
 		}
 
+	case snoozedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			if msg.until == "" {
+				m.statusMsg = fmt.Sprintf("Woke %d issue(s)", len(msg.ids))
+				// Woken on purpose; no need to announce them again
+				for _, id := range msg.ids {
+					delete(m.sleepingIDs, id)
+				}
+			} else {
+				m.statusMsg = fmt.Sprintf("Snoozed %d issue(s) until %s", len(msg.ids), msg.until)
+			}
+			cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+				return clearStatusMsg{}
+			}))
+		}
+		m.marked = make(map[string]bool)
+		if !m.loading {
+			m.loading = true
+			cmds = append(cmds, m.loadTasks())
+		}
+
+	case parentChangedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.statusMsg = fmt.Sprintf("Moved %d issue(s)", msg.count)
+			cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+				return clearStatusMsg{}
+			}))
+		}
+		m.marked = make(map[string]bool)
+		if !m.loading {
+			m.loading = true
+			cmds = append(cmds, m.loadTasks())
+		}
+
 	case taskDeletedMsg:
 		if msg.err != nil {
 			m.err = msg.err
@@ -442,7 +669,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.loading = true
 			cmds = append(cmds, m.loadTasks())
 		}
-		cmds = append(cmds, pollTick())
+		cmds = append(cmds, checkConfig(m.configStamp), pollTick())
+
+	case customCommandDoneMsg:
+		cmds = append(cmds, m.handleCustomCommandDone(msg))
+
+	case patchesAppliedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("applied changes to %d issue(s), then failed: %w", msg.applied, msg.err)
+		} else {
+			m.statusMsg = fmt.Sprintf("Applied changes to %d issue(s)", msg.applied)
+			cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+				return clearStatusMsg{}
+			}))
+		}
+		if !m.loading {
+			m.loading = true
+			cmds = append(cmds, m.loadTasks())
+		}
+
+	case configReloadedMsg:
+		m.handleConfigReloaded(msg)
+		cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		}))
 
 	case commentsLoadedMsg:
 		if msg.err != nil {
@@ -483,6 +733,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		} else {
 			m.statusMsg = "Blocker added!"
+			if msg.depType != models.DepBlocks {
+				m.statusMsg = "Dependency added (" + msg.depType + ")"
+			}
 			cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
 				return clearStatusMsg{}
 			}))
@@ -497,7 +750,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
-			m.statusMsg = "Blocker removed!"
+			m.statusMsg = "Dependency removed!"
 			cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
 				return clearStatusMsg{}
 			}))
@@ -569,6 +822,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.modal.Textarea, cmd = m.modal.Textarea.Update(msg)
 		cmds = append(cmds, cmd)
+	case ViewEditLabels:
+		var cmd tea.Cmd
+		m.modal.Input, cmd = m.modal.Input.Update(msg)
+		m.refreshLabelOptions()
+		cmds = append(cmds, cmd)
+	case ViewEditAssignee:
+		var cmd tea.Cmd
+		m.modal.Input, cmd = m.modal.Input.Update(msg)
+		m.refreshAssigneeOptions()
+		cmds = append(cmds, cmd)
+	case ViewEditDate:
+		var cmd tea.Cmd
+		m.modal.Input, cmd = m.modal.Input.Update(msg)
+		m.updateDatePreview()
+		cmds = append(cmds, cmd)
+	case ViewMoveParent, ViewAddBlocker:
+		// Update picker filter input and narrow the options
+		var cmd tea.Cmd
+		m.modal.Input, cmd = m.modal.Input.Update(msg)
+		m.modal.ApplyFilter()
+		cmds = append(cmds, cmd)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -576,7 +850,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Model) updateSizes() {
 	// Reserve space for help bar (1 line) + margins
-	contentHeight := m.height - 2
+	contentHeight := m.height - 2 - m.bannerHeight()
 	if contentHeight < 0 {
 		contentHeight = 0
 	}
@@ -673,7 +947,7 @@ func (m *Model) updateSizes() {
 		formWidth = 20
 	}
 	m.formTitle.Width = formWidth
-	m.formDesc.Width = formWidth
+	m.formDesc.SetWidth(formWidth)
 
 	// Update help viewport size
 	// Help view: title (2 lines) + content + help bar (1 line)
@@ -683,42 +957,81 @@ func (m *Model) updateSizes() {
 	}
 	m.helpViewport.Width = m.width - 4
 	m.helpViewport.Height = helpHeight
+	m.reportViewport.Width = m.width - 4
+	m.reportViewport.Height = helpHeight
+	m.outputViewport.Width = m.width - 4
+	m.outputViewport.Height = helpHeight
 }
 
-func (m *Model) distributeTasks() {
+// dueStatus classifies a task's due date using the configured thresholds
+func (m *Model) dueStatus(t models.Task) models.DueStatus {
+	return t.DueStatus(time.Now(), m.dueSoonDays, m.dueGraceDays)
+}
+
+// matchesFilter reports whether a task passes the text filter and the quick
+// filter mode
+func (m *Model) matchesFilter(t models.Task) bool {
+	// Apply text filter if set
+	if filterLower := strings.ToLower(m.filterQuery); filterLower != "" {
+		titleLower := strings.ToLower(t.Title)
+		idLower := strings.ToLower(t.ID)
+		if !strings.Contains(titleLower, filterLower) && !strings.Contains(idLower, filterLower) {
+			return false
+		}
+	}
+
+	// Apply quick filter mode
+	switch m.filterMode {
+	case FilterOpen:
+		return t.Status != "closed"
+	case FilterClosed:
+		return t.Status == "closed"
+	case FilterReady:
+		// Ready = open/in_progress AND not blocked
+		return t.Status != "closed" && len(t.BlockedBy) == 0
+	case FilterDeferred:
+		return t.IsDeferred(time.Now())
+	case FilterDue:
+		return m.dueStatus(t) >= models.DueSoon
+	}
+	return true
+}
+
+// indexTasks rebuilds everything derived from the full task list: the lookup
+// map, the dependency graph and its analysis, and the epic rollups. It runs
+// once per load, not on every filter or search change
+func (m *Model) indexTasks() {
 	// Build task lookup map for O(1) access (used for linked issue display)
 	m.tasksMap = make(map[string]*models.Task)
 	for i := range m.tasks {
 		m.tasksMap[m.tasks[i].ID] = &m.tasks[i]
 	}
+	m.depGraph = models.NewDepGraph(m.tasks)
+	m.analyzeDependencies()
+	m.rollups = models.ComputeRollups(m.tasks)
+	if m.mode == ViewReport {
+		m.refreshReport()
+	}
+}
 
+func (m *Model) distributeTasks() {
 	var inProgress, open, closed []models.Task
-	filterLower := strings.ToLower(m.filterQuery)
+	now := time.Now()
+	m.deferredCount = 0
+	m.overdueCount, m.dueSoonCount = 0, 0
 	for _, t := range m.tasks {
-		// Apply text filter if set
-		if filterLower != "" {
-			titleLower := strings.ToLower(t.Title)
-			idLower := strings.ToLower(t.ID)
-			if !strings.Contains(titleLower, filterLower) && !strings.Contains(idLower, filterLower) {
-				continue
-			}
+		switch m.dueStatus(t) {
+		case models.DueOverdue:
+			m.overdueCount++
+		case models.DueSoon:
+			m.dueSoonCount++
 		}
-
-		// Apply quick filter mode
-		switch m.filterMode {
-		case FilterOpen:
-			if t.Status == "closed" {
-				continue
-			}
-		case FilterClosed:
-			if t.Status != "closed" {
-				continue
-			}
-		case FilterReady:
-			// Ready = open/in_progress AND not blocked
-			if t.Status == "closed" || len(t.BlockedBy) > 0 {
-				continue
-			}
+		if !m.matchesFilter(t) {
+			continue
+		}
+		if m.isHiddenDeferred(t, now) {
+			m.deferredCount++
+			continue
 		}
 
 		switch t.Status {
@@ -843,6 +1156,11 @@ func (m *Model) flattenTree(tasks []models.Task, sortFn func([]models.Task)) []t
 				depth:       depth,
 				hasChildren: hasChildren,
 				expanded:    hasChildren && !collapsed,
+				marked:      m.marked[t.ID],
+				unblocks:    m.unblockCounts[t.ID],
+				inCycle:     m.cycleIDs[t.ID],
+				rollup:      m.rollups[t.ID],
+				due:         m.dueStatus(t),
 			})
 			if hasChildren && !collapsed {
 				walk(t.ID, depth+1)
@@ -875,6 +1193,66 @@ func (m *Model) selectTaskByID(id string) {
 	}
 }
 
+// targetTasks returns the tasks a bulk action applies to: the marked tasks
+// if any, otherwise the selected task.
+func (m *Model) targetTasks() []*models.Task {
+	var targets []*models.Task
+	for i := range m.tasks {
+		if m.marked[m.tasks[i].ID] {
+			targets = append(targets, &m.tasks[i])
+		}
+	}
+	if len(targets) == 0 {
+		if task := m.getSelectedTask(); task != nil {
+			targets = append(targets, task)
+		}
+	}
+	return targets
+}
+
+// isDescendantOf reports whether id sits somewhere below ancestorID in the
+// hierarchy (walking parent links from both dependencies and dot notation)
+func (m *Model) isDescendantOf(id, ancestorID string) bool {
+	seen := make(map[string]bool)
+	for current := id; current != "" && !seen[current]; {
+		seen[current] = true
+		var parentID string
+		if t, ok := m.tasksMap[current]; ok {
+			parentID = t.GetParentID()
+		} else {
+			parentID = models.ParentID(current)
+		}
+		if parentID == ancestorID {
+			return true
+		}
+		current = parentID
+	}
+	return false
+}
+
+// revealTask focuses the panel containing the given task and selects it.
+// Returns false if the task is not shown in any panel.
+func (m *Model) revealTask(id string) bool {
+	panels := map[PanelFocus]*PanelModel{
+		FocusInProgress: &m.inProgressPanel,
+		FocusOpen:       &m.openPanel,
+		FocusClosed:     &m.closedPanel,
+	}
+	for _, focus := range m.getVisiblePanels() {
+		for _, t := range panels[focus].tasks {
+			if t.ID == id {
+				if m.focusedPanel != focus {
+					m.focusPanelByType(focus)
+				}
+				m.selectTaskByID(id)
+				m.selected = m.getSelectedTask()
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // getBoardSelectedTask returns the currently selected task in board view
 // Board has 5 columns: 0=Blocked, 1=Open, 2=Ready, 3=In Progress, 4=Done
 func (m *Model) getBoardSelectedTask() *models.Task {
@@ -888,11 +1266,18 @@ func (m *Model) getBoardSelectedTask() *models.Task {
 	return nil
 }
 
+// boardColumnCount is the number of board columns
+const boardColumnCount = 5
+
 // getBoardColumns returns task lists for all 5 board columns
 // 0=Blocked, 1=Open, 2=Ready, 3=In Progress, 4=Done
-func (m *Model) getBoardColumns() [5][]models.Task {
-	var columns [5][]models.Task
+func (m *Model) getBoardColumns() [boardColumnCount][]models.Task {
+	var columns [boardColumnCount][]models.Task
+	now := time.Now()
 	for _, t := range m.tasks {
+		if m.isHiddenDeferred(t, now) {
+			continue
+		}
 		switch t.Status {
 		case "open":
 			if t.IsBlocked() {
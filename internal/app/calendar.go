@@ -0,0 +1,452 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/josebiro/bb/internal/beads"
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+const (
+	// calendarTrayWidth is the width of the overdue tray beside the grid
+	calendarTrayWidth = 34
+	// calendarMinCellHeight is the smallest day cell: the date and one issue
+	calendarMinCellHeight = 2
+)
+
+// calendarEntry is an issue placed on a calendar day
+type calendarEntry struct {
+	task     *models.Task
+	deferred bool // placed on its defer date rather than its due date
+}
+
+// calendarEntries places every issue passing the current filter on its due
+// date, and open deferred issues on their defer-until date
+func (m *Model) calendarEntries() map[time.Time][]calendarEntry {
+	days := make(map[time.Time][]calendarEntry)
+	for i := range m.tasks {
+		t := &m.tasks[i]
+		if !m.matchesFilter(*t) {
+			continue
+		}
+		if t.DueDate != nil {
+			day := dateOnly(*t.DueDate)
+			days[day] = append(days[day], calendarEntry{task: t})
+		}
+		if t.DeferUntil != nil && t.Status != "closed" {
+			day := dateOnly(*t.DeferUntil)
+			days[day] = append(days[day], calendarEntry{task: t, deferred: true})
+		}
+	}
+	for _, entries := range days {
+		sort.SliceStable(entries, func(i, j int) bool {
+			a, b := entries[i], entries[j]
+			if a.deferred != b.deferred {
+				return b.deferred
+			}
+			if a.task.Priority != b.task.Priority {
+				return a.task.Priority < b.task.Priority
+			}
+			return a.task.ID < b.task.ID
+		})
+	}
+	return days
+}
+
+// overdueTasks returns open issues whose due date has passed (allowing for
+// the configured grace period), oldest first
+func (m *Model) overdueTasks() []*models.Task {
+	var overdue []*models.Task
+	for i := range m.tasks {
+		t := &m.tasks[i]
+		if m.dueStatus(*t) == models.DueOverdue && m.matchesFilter(*t) {
+			overdue = append(overdue, t)
+		}
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		a, b := dateOnly(*overdue[i].DueDate), dateOnly(*overdue[j].DueDate)
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return overdue[i].ID < overdue[j].ID
+	})
+	return overdue
+}
+
+// openCalendar shows the calendar on today's month
+func (m *Model) openCalendar() {
+	m.calendarCursor = todayDate()
+	m.calendarEntry = 0
+	m.calendarTray = false
+	m.calendarMoveID = ""
+	m.mode = ViewCalendar
+}
+
+// calendarGrid returns the first day shown and the number of weeks: the
+// Monday-aligned weeks covering the cursor's month, or just its week
+func (m Model) calendarGrid() (time.Time, int) {
+	if m.calendarWeekly {
+		return alignTimeline(m.calendarCursor, zoomWeek), 1
+	}
+	first := time.Date(m.calendarCursor.Year(), m.calendarCursor.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	start := alignTimeline(first, zoomWeek)
+	return start, (daysBetween(start, last) + 7) / 7
+}
+
+// selectedCalendarTask returns the issue under the cursor: the highlighted
+// tray entry or the highlighted entry of the cursor day
+func (m *Model) selectedCalendarTask() (*models.Task, bool) {
+	if m.calendarTray {
+		overdue := m.overdueTasks()
+		if m.calendarEntry < len(overdue) {
+			return overdue[m.calendarEntry], false
+		}
+		return nil, false
+	}
+	entries := m.calendarEntries()[m.calendarCursor]
+	if m.calendarEntry < len(entries) {
+		return entries[m.calendarEntry].task, entries[m.calendarEntry].deferred
+	}
+	return nil, false
+}
+
+// moveCalendarCursor moves the day cursor, leaving the tray
+func (m *Model) moveCalendarCursor(days int) {
+	m.calendarCursor = m.calendarCursor.AddDate(0, 0, days)
+	m.calendarEntry = 0
+	m.calendarTray = false
+}
+
+func (m *Model) handleCalendarKeys(msg tea.KeyMsg) tea.Cmd {
+	if m.calendarMoveID != "" {
+		return m.handleCalendarMoveKeys(msg)
+	}
+
+	var count int
+	if m.calendarTray {
+		count = len(m.overdueTasks())
+	} else {
+		count = len(m.calendarEntries()[m.calendarCursor])
+	}
+
+	switch {
+	case m.calendarTray && key.Matches(msg, m.keys.List.Up):
+		if m.calendarEntry > 0 {
+			m.calendarEntry--
+		}
+	case m.calendarTray && key.Matches(msg, m.keys.List.Down):
+		if m.calendarEntry < count-1 {
+			m.calendarEntry++
+		}
+	case key.Matches(msg, m.keys.List.PrevView): // h/left
+		m.moveCalendarCursor(-1)
+	case key.Matches(msg, m.keys.List.NextView): // l/right
+		m.moveCalendarCursor(1)
+	case key.Matches(msg, m.keys.List.Up):
+		m.moveCalendarCursor(-7)
+	case key.Matches(msg, m.keys.List.Down):
+		m.moveCalendarCursor(7)
+	case key.Matches(msg, m.keys.List.HistoryBack): // [ - previous period
+		if m.calendarWeekly {
+			m.moveCalendarCursor(-7)
+		} else {
+			m.calendarCursor = m.calendarCursor.AddDate(0, -1, 0)
+			m.calendarEntry = 0
+		}
+	case key.Matches(msg, m.keys.List.HistoryForward): // ] - next period
+		if m.calendarWeekly {
+			m.moveCalendarCursor(7)
+		} else {
+			m.calendarCursor = m.calendarCursor.AddDate(0, 1, 0)
+			m.calendarEntry = 0
+		}
+	case key.Matches(msg, m.keys.List.Tab):
+		if count > 0 {
+			m.calendarEntry = (m.calendarEntry + 1) % count
+		}
+	case key.Matches(msg, m.keys.List.ShiftTab):
+		if count > 0 {
+			m.calendarEntry = (m.calendarEntry + count - 1) % count
+		}
+	case msg.String() == ".":
+		m.moveCalendarCursor(daysBetween(m.calendarCursor, todayDate()))
+	case msg.String() == "w":
+		m.calendarWeekly = !m.calendarWeekly
+	case msg.String() == "t": // toggle focus on the overdue tray
+		m.calendarTray = !m.calendarTray
+		m.calendarEntry = 0
+	case key.Matches(msg, m.keys.List.MoveParent): // m - move to another day
+		task, deferred := m.selectedCalendarTask()
+		if task == nil {
+			return nil
+		}
+		m.calendarMoveID = task.ID
+		m.calendarMoveDefer = deferred
+		if m.calendarTray {
+			// Overdue issues usually move forward; start from today
+			m.calendarTray = false
+			m.calendarCursor = todayDate()
+		}
+	case key.Matches(msg, m.keys.List.Select):
+		if task, _ := m.selectedCalendarTask(); task != nil {
+			m.revealTask(task.ID)
+			m.selected = task
+			m.comments = nil
+			m.updateDetailContent()
+			m.previousMode = ViewCalendar
+			m.mode = ViewDetail
+			return m.loadComments(task.ID)
+		}
+	case key.Matches(msg, m.keys.List.Calendar):
+		m.mode = ViewList
+	case key.Matches(msg, m.keys.List.Help):
+		m.mode = ViewHelp
+	}
+	return nil
+}
+
+// handleCalendarMoveKeys moves the day cursor while an issue is being moved
+// and saves the new date on enter
+func (m *Model) handleCalendarMoveKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.List.PrevView):
+		m.moveCalendarCursor(-1)
+	case key.Matches(msg, m.keys.List.NextView):
+		m.moveCalendarCursor(1)
+	case key.Matches(msg, m.keys.List.Up):
+		m.moveCalendarCursor(-7)
+	case key.Matches(msg, m.keys.List.Down):
+		m.moveCalendarCursor(7)
+	case key.Matches(msg, m.keys.List.HistoryBack):
+		m.calendarCursor = m.calendarCursor.AddDate(0, -1, 0)
+	case key.Matches(msg, m.keys.List.HistoryForward):
+		m.calendarCursor = m.calendarCursor.AddDate(0, 1, 0)
+	case msg.String() == ".":
+		m.moveCalendarCursor(daysBetween(m.calendarCursor, todayDate()))
+	case key.Matches(msg, m.keys.List.Select):
+		taskID := m.calendarMoveID
+		deferred := m.calendarMoveDefer
+		value := m.calendarCursor.Format(models.DateLayout)
+		m.calendarMoveID = ""
+		field := "Due"
+		if deferred {
+			field = "Defer"
+		}
+		status := fmt.Sprintf("%s date of %s set to %s", field, taskID, value)
+		return func() tea.Msg {
+			var opts beads.UpdateOptions
+			if deferred {
+				opts.DeferUntil = &value
+			} else {
+				opts.DueDate = &value
+			}
+			err := m.client.Update(taskID, opts)
+			return taskUpdatedMsg{status: status, err: err}
+		}
+	}
+	return nil
+}
+
+// calendarEntryLine renders one issue inside a day cell
+func (m Model) calendarEntryLine(e calendarEntry, width int, selected bool) string {
+	marker := " "
+	if e.deferred {
+		marker = "↷"
+	}
+	text := padText(ui.Truncate(marker+e.task.PriorityString()+" "+e.task.ID+" "+e.task.Title, width), width)
+	if selected {
+		return ui.SelectedRowStyle.Render(text)
+	}
+	style := lipgloss.NewStyle()
+	switch {
+	case e.task.Status == "closed":
+		style = style.Foreground(ui.ColorMuted).Strikethrough(true)
+	case e.deferred:
+		style = style.Foreground(ui.ColorMuted).Italic(true)
+	case m.dueStatus(*e.task) == models.DueOverdue:
+		style = style.Foreground(ui.ColorDanger)
+	}
+	return style.Render(text)
+}
+
+// calendarCell renders the lines of one day
+func (m Model) calendarCell(day time.Time, entries []calendarEntry, width, height int) []string {
+	cursor := day.Equal(m.calendarCursor)
+	lines := make([]string, 0, height)
+
+	label := padText(fmt.Sprintf(" %2d", day.Day()), width)
+	if day.Day() == 1 || (m.calendarWeekly && day.Equal(alignTimeline(day, zoomWeek))) {
+		label = padText(" "+day.Format("Jan 2"), width)
+	}
+	labelStyle := lipgloss.NewStyle()
+	switch {
+	case cursor:
+		labelStyle = labelStyle.Reverse(true).Bold(true)
+	case day.Equal(todayDate()):
+		labelStyle = labelStyle.Foreground(ui.ColorWarning).Bold(true)
+	case !m.calendarWeekly && day.Month() != m.calendarCursor.Month():
+		labelStyle = labelStyle.Foreground(ui.ColorMuted)
+	}
+	lines = append(lines, labelStyle.Render(label))
+
+	// The issue being moved previews on the cursor day
+	if cursor && m.calendarMoveID != "" && len(lines) < height {
+		ghost := padText(ui.Truncate("→ "+m.calendarMoveID, width), width)
+		lines = append(lines, lipgloss.NewStyle().Foreground(ui.ColorAccent).Bold(true).Render(ghost))
+	}
+
+	room := height - len(lines)
+	selected := -1
+	if cursor && !m.calendarTray && m.calendarMoveID == "" {
+		selected = m.calendarEntry
+	}
+	// Scroll the cursor day so its selected entry stays visible
+	first := 0
+	if len(entries) > room && selected >= room-1 {
+		first = selected - room + 2
+	}
+	for i := first; i < len(entries) && len(lines) < height; i++ {
+		if len(lines) == height-1 && i < len(entries)-1 {
+			more := fmt.Sprintf(" +%d more", len(entries)-i)
+			lines = append(lines, ui.HelpDescStyle.Render(padText(more, width)))
+			break
+		}
+		lines = append(lines, m.calendarEntryLine(entries[i], width, i == selected))
+	}
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+	return lines
+}
+
+// calendarTrayView renders the overdue tray
+func (m Model) calendarTrayView(width, height int) []string {
+	overdue := m.overdueTasks()
+	now := todayDate()
+	title := fmt.Sprintf(" Overdue (%d)", len(overdue))
+	titleStyle := lipgloss.NewStyle().Foreground(ui.ColorDanger).Bold(true)
+	if m.calendarTray {
+		titleStyle = titleStyle.Reverse(true)
+	}
+	lines := []string{titleStyle.Render(padText(title, width))}
+	if len(overdue) == 0 {
+		lines = append(lines, ui.HelpDescStyle.Render(padText(" Nothing overdue", width)))
+	}
+
+	first := 0
+	if m.calendarTray && m.calendarEntry >= height-1 {
+		first = m.calendarEntry - height + 2
+	}
+	for i := first; i < len(overdue) && len(lines) < height; i++ {
+		t := overdue[i]
+		late := daysBetween(dateOnly(*t.DueDate), now)
+		text := padText(ui.Truncate(fmt.Sprintf(" %3dd %s %s", late, t.ID, t.Title), width), width)
+		if m.calendarTray && i == m.calendarEntry {
+			lines = append(lines, ui.SelectedRowStyle.Render(text))
+			continue
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(ui.ColorDanger).Render(text))
+	}
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+	return lines
+}
+
+func (m Model) viewCalendar() string {
+	var b strings.Builder
+
+	start, weeks := m.calendarGrid()
+	var title string
+	if m.calendarWeekly {
+		end := start.AddDate(0, 0, 6)
+		title = fmt.Sprintf("CALENDAR  week of %s – %s", start.Format("Jan 2"), end.Format("Jan 2 2006"))
+	} else {
+		title = "CALENDAR  " + m.calendarCursor.Format("January 2006")
+	}
+	b.WriteString(ui.TitleStyle.Render(title) + "\n")
+
+	trayWidth := calendarTrayWidth
+	if m.width < 100 {
+		trayWidth = m.width / 4
+	}
+	colWidth := (m.width - trayWidth - 1 - 6) / 7 // 6 separators, 1 before the tray
+	if colWidth < 6 {
+		colWidth = 6
+	}
+
+	// Weekday header
+	sep := lipgloss.NewStyle().Foreground(ui.ColorBorder).Render("│")
+	var header []string
+	for i := 0; i < 7; i++ {
+		header = append(header, padText(" "+start.AddDate(0, 0, i).Format("Mon"), colWidth))
+	}
+	b.WriteString(ui.HelpDescStyle.Render(strings.Join(header, " ")) + "\n")
+
+	bodyHeight := m.height - 4
+	if bodyHeight < weeks*(calendarMinCellHeight+1) {
+		bodyHeight = weeks * (calendarMinCellHeight + 1)
+	}
+	cellHeight := (bodyHeight - (weeks - 1)) / weeks
+
+	entries := m.calendarEntries()
+	var grid []string
+	rule := lipgloss.NewStyle().Foreground(ui.ColorBorder).Render(
+		strings.TrimSuffix(strings.Repeat(strings.Repeat("─", colWidth)+"┼", 7), "┼"))
+	for w := 0; w < weeks; w++ {
+		if w > 0 {
+			grid = append(grid, rule)
+		}
+		cells := make([][]string, 7)
+		for d := 0; d < 7; d++ {
+			day := start.AddDate(0, 0, 7*w+d)
+			cells[d] = m.calendarCell(day, entries[day], colWidth, cellHeight)
+		}
+		for y := 0; y < cellHeight; y++ {
+			row := make([]string, 7)
+			for d := range cells {
+				row[d] = cells[d][y]
+			}
+			grid = append(grid, strings.Join(row, sep))
+		}
+	}
+
+	tray := m.calendarTrayView(trayWidth, len(grid))
+	for i := range grid {
+		grid[i] += sep + tray[i]
+	}
+	b.WriteString(strings.Join(grid, "\n") + "\n")
+
+	// What the cursor is on, or how to finish a move
+	var info string
+	switch {
+	case m.statusMsg != "":
+		info = ui.SuccessStyle.Render(m.statusMsg)
+	case m.calendarMoveID != "":
+		info = lipgloss.NewStyle().Foreground(ui.ColorAccent).Render(
+			fmt.Sprintf("Moving %s to %s  (h/j/k/l pick a day, enter drop, esc cancel)",
+				m.calendarMoveID, m.calendarCursor.Format("Mon "+models.DateLayout)))
+	default:
+		text := m.calendarCursor.Format("Mon " + models.DateLayout)
+		if task, deferred := m.selectedCalendarTask(); task != nil {
+			text += "  " + task.ID + " " + task.Title + " [" + task.Status + "]"
+			if deferred {
+				text += "  (deferred until this day)"
+			}
+		}
+		info = ui.HelpDescStyle.Render(ui.Truncate(text, m.width))
+	}
+	b.WriteString(info + "\n")
+
+	b.WriteString(ui.HelpBarStyle.Render("h/l:day  j/k:week  [/]:period  .:today  w:month/week  tab:issue  t:overdue  m:move  enter:details  W/esc:back"))
+	return b.String()
+}
@@ -0,0 +1,282 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/config"
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// commandFuncs are the functions available to custom command templates
+var commandFuncs = template.FuncMap{
+	"sh":    shellEscape,
+	"quote": shellQuote,
+}
+
+// commandVars are the variables available to custom command templates: the
+// selected issue's fields, the issues the command applies to as .Tasks, and
+// the answers to the command's prompts as .Prompt.<name>
+type commandVars struct {
+	*models.Task
+	Tasks  []*models.Task
+	Prompt map[string]any
+}
+
+// commandRun is a rendered custom command and the issue it was rendered for
+type commandRun struct {
+	taskID  string
+	command string
+}
+
+// newCommandTemplate returns an empty custom command template. Referring to
+// a prompt the command does not declare is an error rather than "<no value>".
+func newCommandTemplate() *template.Template {
+	return template.New("cmd").Funcs(commandFuncs).Option("missingkey=error")
+}
+
+// customCommandDoneMsg is sent when a custom command exits. A command run
+// once per issue reports once, after the last run.
+type customCommandDoneMsg struct {
+	cmd      config.CustomCommand
+	output   string // combined stdout and stderr (background and capture modes)
+	runs     int    // number of times the command ran
+	failed   int    // runs that exited non-zero or did not start
+	exitCode int    // status of the last failed run; -1 if it did not start
+	err      error  // error of the last failed run
+
+	stdouts []runOutput // stdout of each run, kept apart for patch mode
+}
+
+// record adds the result of one run
+func (msg *customCommandDoneMsg) record(err error) {
+	msg.runs++
+	if err != nil {
+		msg.failed++
+		msg.exitCode = exitCode(err)
+		msg.err = err
+	}
+}
+
+const (
+	// commandOutputLimit is how much of a command's output is kept; earlier
+	// output is dropped
+	commandOutputLimit = 1 << 20
+	// commandWaitDelay is how long to keep reading output after a command
+	// exits. Children it leaves running (editors, GUI apps, "foo &") may
+	// hold the output open indefinitely.
+	commandWaitDelay = time.Second
+)
+
+// tailBuffer is a writer that keeps the last limit bytes written to it
+type tailBuffer struct {
+	limit   int
+	buf     []byte
+	dropped bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if over := len(b.buf) - b.limit; over > 0 {
+		b.buf = b.buf[:copy(b.buf, b.buf[over:])]
+		b.dropped = true
+	}
+	return len(p), nil
+}
+
+// String returns the kept output, marking that earlier output was dropped
+func (b *tailBuffer) String() string {
+	if b.dropped {
+		return "… (earlier output dropped)\n" + string(b.buf)
+	}
+	return string(b.buf)
+}
+
+// runCommandsInBackground runs the commands one after the other without
+// blocking the UI, collecting the tail of their output. Output of several
+// runs is headed by the issue each ran for.
+func runCommandsInBackground(cmd config.CustomCommand, runs []commandRun) tea.Cmd {
+	return func() tea.Msg {
+		done := customCommandDoneMsg{cmd: cmd}
+		out := &tailBuffer{limit: commandOutputLimit}
+		for _, run := range runs {
+			if len(runs) > 1 {
+				fmt.Fprintf(out, "── %s ──\n", run.taskID)
+			}
+			c := exec.Command("sh", "-c", run.command)
+			stdout := &tailBuffer{limit: commandOutputLimit}
+			c.Stdout = out
+			if cmd.Mode == "patch" {
+				c.Stdout = stdout
+			}
+			c.Stderr = out
+			c.WaitDelay = commandWaitDelay
+			err := c.Run()
+			if errors.Is(err, exec.ErrWaitDelay) {
+				// The command succeeded; a child it started kept the output open
+				err = nil
+			}
+			if err == nil && stdout.dropped {
+				err = fmt.Errorf("output exceeds %d bytes", commandOutputLimit)
+			}
+			done.record(err)
+			done.stdouts = append(done.stdouts, runOutput{taskID: run.taskID, stdout: string(stdout.buf)})
+		}
+		done.output = out.String()
+		return done
+	}
+}
+
+// runCommandsInteractively hands the terminal to each command in turn
+// (editors, shells) until it exits
+func runCommandsInteractively(cmd config.CustomCommand, runs []commandRun) tea.Cmd {
+	done := &customCommandDoneMsg{cmd: cmd}
+	var steps []tea.Cmd
+	for i, run := range runs {
+		last := i == len(runs)-1
+		steps = append(steps, tea.ExecProcess(exec.Command("sh", "-c", run.command), func(err error) tea.Msg {
+			// Runs happen in order, so only the last one reports
+			done.record(err)
+			if !last {
+				return nil
+			}
+			return *done
+		}))
+	}
+	return tea.Sequence(steps...)
+}
+
+// exitCode returns the exit status a command's error reports: 0 on
+// success, -1 if it did not run at all
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// handleCustomCommandDone reports how a custom command ended: in the output
+// overlay for capture mode, as a preview of its changes for patch mode, in
+// the status bar otherwise. Issues are
+// reloaded since commands often change them through bd.
+func (m *Model) handleCustomCommandDone(msg customCommandDoneMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	if !m.loading {
+		m.loading = true
+		cmds = append(cmds, m.loadTasks())
+	}
+
+	switch {
+	case msg.cmd.Mode == "capture":
+		m.statusMsg = ""
+		m.openCommandOutput(msg)
+	case msg.cmd.Mode == "patch" && msg.failed == 0:
+		m.statusMsg = ""
+		cmds = append(cmds, m.previewPatches(msg))
+	case msg.failed > 0 && msg.runs > 1:
+		m.err = fmt.Errorf("%s failed for %d of %d issues: %s", msg.cmd.Description, msg.failed, msg.runs, failureSummary(msg))
+	case msg.failed > 0:
+		m.err = fmt.Errorf("%s failed: %s", msg.cmd.Description, failureSummary(msg))
+	case msg.runs > 1:
+		m.statusMsg = fmt.Sprintf("%s done for %d issues", msg.cmd.Description, msg.runs)
+		cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		}))
+	default:
+		m.statusMsg = msg.cmd.Description + " done"
+		cmds = append(cmds, tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// failureSummary describes a failed command by its exit status and the last
+// line it printed
+func failureSummary(msg customCommandDoneMsg) string {
+	summary := msg.err.Error()
+	if msg.exitCode > 0 {
+		summary = fmt.Sprintf("exit %d", msg.exitCode)
+	}
+	lines := strings.Split(strings.TrimSpace(msg.output), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		summary += ": " + last
+	}
+	return summary
+}
+
+// openCommandOutput shows a captured command's output in a scrollable overlay
+func (m *Model) openCommandOutput(msg customCommandDoneMsg) {
+	status := ui.SuccessStyle.Render("exit 0")
+	switch {
+	case msg.failed > 0 && msg.runs > 1:
+		status = ui.ErrorStyle.Render(fmt.Sprintf("%d of %d failed", msg.failed, msg.runs))
+	case msg.exitCode > 0:
+		status = ui.ErrorStyle.Render(fmt.Sprintf("exit %d", msg.exitCode))
+	case msg.err != nil:
+		status = ui.ErrorStyle.Render(msg.err.Error())
+	}
+
+	output := strings.TrimRight(msg.output, "\n")
+	if output == "" {
+		output = ui.HelpDescStyle.Render("(no output)")
+	}
+	m.outputTitle = msg.cmd.Description + "  " + status
+	m.outputViewport.SetContent(output)
+	m.outputViewport.GotoTop()
+	if m.mode != ViewCommandOutput {
+		m.outputReturn = m.mode
+	}
+	m.mode = ViewCommandOutput
+}
+
+func (m *Model) handleCommandOutputKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.List.Select), key.Matches(msg, m.keys.List.Quit):
+		m.mode = m.outputReturn
+	case key.Matches(msg, m.keys.List.Up):
+		m.outputViewport.LineUp(1)
+	case key.Matches(msg, m.keys.List.Down):
+		m.outputViewport.LineDown(1)
+	case key.Matches(msg, m.keys.List.PageUp):
+		m.outputViewport.HalfViewUp()
+	case key.Matches(msg, m.keys.List.PageDown):
+		m.outputViewport.HalfViewDown()
+	case key.Matches(msg, m.keys.List.Top):
+		m.outputViewport.GotoTop()
+	case key.Matches(msg, m.keys.List.Bottom):
+		m.outputViewport.GotoBottom()
+	}
+	return nil
+}
+
+func (m Model) viewCommandOutput() string {
+	var b strings.Builder
+
+	b.WriteString(ui.TitleStyle.Render(m.outputTitle) + "\n\n")
+	b.WriteString(ui.OverlayStyle.
+		Width(m.width - 4).
+		Height(m.outputViewport.Height).
+		Render(m.outputViewport.View()))
+	b.WriteString("\n")
+
+	scrollInfo := fmt.Sprintf("%d%%", int(m.outputViewport.ScrollPercent()*100))
+	helpBar := fmt.Sprintf("%s:scroll  %s:page  %s/%s:close  %s",
+		ui.HelpKey(m.keys.List.Up), ui.HelpKey(m.keys.List.PageUp),
+		ui.HelpKey(m.keys.List.Select), ui.HelpKey(m.keys.List.Cancel), scrollInfo)
+	b.WriteString(ui.HelpBarStyle.Render(helpBar))
+
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", `''`},
+		{"plain", `'plain'`},
+		{"two words", `'two words'`},
+		{"it's", `'it'\''s'`},
+		{`say "hi"`, `'say "hi"'`},
+		{"$HOME", `'$HOME'`},
+		{`a\b`, `'a\b'`},
+		{"`id`", "'`id`'"},
+		{"x; rm -rf /", `'x; rm -rf /'`},
+	}
+	for _, tt := range tests {
+		got := shellQuote(tt.input)
+		if got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.input, got, tt.want)
+			continue
+		}
+		// The shell must see the input unchanged, as a single word
+		out, err := exec.Command("sh", "-c", "printf %s "+got).Output()
+		if err != nil {
+			t.Errorf("sh -c printf %s: %v", got, err)
+		} else if string(out) != tt.input {
+			t.Errorf("sh read %s as %q, want %q", got, out, tt.input)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/josebiro/bb/internal/config"
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// ConfigProblems returns every problem with a config: those found while
+// loading it, plus themes, keybindings and templates that bb cannot use.
+// Problems are sorted by file and line.
+func ConfigProblems(cfg *config.Config) []config.Problem {
+	problems := append([]config.Problem(nil), cfg.Problems...)
+	_, themeProblems := resolveTheme(cfg)
+	problems = append(problems, themeProblems...)
+	_, keyProblems := buildKeyMaps(cfg)
+	problems = append(problems, keyProblems...)
+	problems = append(problems, templateProblems(cfg)...)
+	config.SortProblems(problems)
+	return problems
+}
+
+// resolveTheme returns the configured theme. An unknown theme name falls
+// back to the default theme and invalid colors are skipped, so one bad
+// setting does not discard the rest.
+func resolveTheme(cfg *config.Config) (ui.Theme, []config.Problem) {
+	var problems []config.Problem
+	name := cfg.Theme.Name
+	if _, err := ui.ResolveTheme(name, nil); err != nil {
+		problems = append(problems, config.Problem{Location: cfg.Locate("theme.name"), Message: err.Error()})
+		name = ""
+	}
+
+	names := make([]string, 0, len(cfg.Theme.Colors))
+	for color := range cfg.Theme.Colors {
+		names = append(names, color)
+	}
+	sort.Strings(names)
+	colors := make(map[string]string)
+	for _, color := range names {
+		override := map[string]string{color: cfg.Theme.Colors[color]}
+		if _, err := ui.ResolveTheme(name, override); err != nil {
+			problems = append(problems, config.Problem{Location: cfg.Locate("theme.colors." + color), Message: err.Error()})
+			continue
+		}
+		colors[color] = cfg.Theme.Colors[color]
+	}
+
+	theme, _ := ui.ResolveTheme(name, colors)
+	return theme, problems
+}
+
+// sampleTask is a task with every field set, used to catch custom command
+// templates that refer to fields models.Task does not have
+func sampleTask() *models.Task {
+	now := time.Now()
+	return &models.Task{
+		ID:         "bb-1",
+		Title:      "Sample",
+		Status:     "open",
+		Type:       "task",
+		Labels:     []string{"sample"},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ClosedAt:   &now,
+		DueDate:    &now,
+		DeferUntil: &now,
+		BlockedBy:  []string{"bb-2"},
+		Blocks:     []string{"bb-3"},
+	}
+}
+
+// samplePromptAnswer is a plausible answer to a prompt, for checking
+// command templates
+func samplePromptAnswer(p config.CommandPrompt) any {
+	switch p.Type {
+	case "confirm":
+		return true
+	case "choice":
+		if len(p.Options) > 0 {
+			return p.Options[0]
+		}
+	case "issue":
+		return "bb-2"
+	}
+	return "sample"
+}
+
+// templateProblems parses custom command and issue templates and runs them
+// against sample data
+func templateProblems(cfg *config.Config) []config.Problem {
+	var problems []config.Problem
+	for _, cmd := range cfg.CustomCommands {
+		if cmd.Key == "" {
+			continue
+		}
+		task := sampleTask()
+		vars := commandVars{Task: task, Tasks: []*models.Task{task}, Prompt: make(map[string]any)}
+		for _, p := range cmd.Prompts {
+			vars.Prompt[p.Name] = samplePromptAnswer(p)
+		}
+		if err := checkTemplate(newCommandTemplate(), cmd.Command, vars); err != nil {
+			problems = append(problems, config.Problem{
+				Location: cfg.Locate("customCommands." + cmd.Key),
+				Message:  fmt.Sprintf("custom command %q: %v", cmd.Key, err),
+			})
+		}
+	}
+
+	vars := templateVars{ParentID: "bb-1", Title: "Sample", Type: "task", Date: today()}
+	for _, t := range cfg.Templates {
+		name := t.Name
+		if name == "" {
+			name = t.Type
+		}
+		report := func(field string, err error) {
+			problems = append(problems, config.Problem{
+				Location: cfg.Locate("templates." + name),
+				Message:  fmt.Sprintf("template %q: %s: %v", name, field, err),
+			})
+		}
+		if err := checkTemplate(template.New("issue"), t.TitlePrefix, vars); err != nil {
+			report("titlePrefix", err)
+		}
+		if err := checkTemplate(template.New("issue"), t.Description, vars); err != nil {
+			report("description", err)
+		}
+		var walk func(children []config.TemplateChild)
+		walk = func(children []config.TemplateChild) {
+			for _, child := range children {
+				if err := checkTemplate(template.New("issue"), child.Title, vars); err != nil {
+					report(fmt.Sprintf("child %q title", child.Title), err)
+				}
+				if err := checkTemplate(template.New("issue"), child.Description, vars); err != nil {
+					report(fmt.Sprintf("child %q description", child.Title), err)
+				}
+				walk(child.Children)
+			}
+		}
+		walk(t.Children)
+	}
+	return problems
+}
+
+// checkTemplate parses text and executes it against data
+func checkTemplate(tmpl *template.Template, text string, data any) error {
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(io.Discard, data)
+}
+
+// bannerHeight is the number of lines the config problems banner takes
+func (m *Model) bannerHeight() int {
+	if len(m.configProblems) == 0 {
+		return 0
+	}
+	return 1
+}
+
+// renderConfigBanner renders a one-line summary of the config problems,
+// or "" when there are none
+func (m *Model) renderConfigBanner() string {
+	if len(m.configProblems) == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("⚠ %d config problem(s): %s", len(m.configProblems), m.configProblems[0].Error())
+	hint := " · bb --config for details, esc to dismiss"
+	width := m.width - lipgloss.Width(hint)
+	if width < 10 {
+		width = 10
+	}
+	text = ui.Truncate(text, width)
+	return lipgloss.NewStyle().Foreground(ui.ColorWarning).Bold(true).Render(text) + ui.HelpDescStyle.Render(hint)
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/beads"
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+const (
+	snoozeCustom = "custom"
+	snoozeWake   = "wake"
+
+	// wakeFlashDuration keeps wake-up notices up longer than other flashes
+	// since they are not a response to a keypress
+	wakeFlashDuration = 5 * time.Second
+)
+
+// isHiddenDeferred reports whether a task is left out of the panels and the
+// board because it is deferred to a later day. The Deferred filter shows
+// only such tasks.
+func (m *Model) isHiddenDeferred(t models.Task, now time.Time) bool {
+	return m.filterMode != FilterDeferred && t.IsDeferred(now)
+}
+
+// openSnooze offers to defer the given issues by a day, a week or until a
+// chosen date, or to wake them now
+func (m *Model) openSnooze(targets []*models.Task) {
+	m.snoozeIDs = nil
+	anyDeferred := false
+	for _, t := range targets {
+		m.snoozeIDs = append(m.snoozeIDs, t.ID)
+		anyDeferred = anyDeferred || t.DeferUntil != nil
+	}
+	options := []ui.ModalOption{
+		{Label: "1 day", Value: "+1d", Shortcut: "d"},
+		{Label: "1 week", Value: "+1w", Shortcut: "w"},
+		{Label: "Until…", Value: snoozeCustom, Shortcut: "c"},
+	}
+	if anyDeferred {
+		options = append(options, ui.ModalOption{Label: "Wake now", Value: snoozeWake, Shortcut: "x"})
+	}
+	m.modal = ui.NewModalSelect("Snooze", m.snoozeSubject(), options, "+1d")
+	m.mode = ViewSnooze
+}
+
+// snoozeSubject names the issues being snoozed for modal subtitles
+func (m *Model) snoozeSubject() string {
+	if len(m.snoozeIDs) == 1 {
+		return m.snoozeIDs[0]
+	}
+	return fmt.Sprintf("%d issues", len(m.snoozeIDs))
+}
+
+func (m *Model) handleSnoozeKeys(msg tea.KeyMsg) tea.Cmd {
+	shortcut := m.modal.SelectByShortcut(msg.String())
+
+	switch {
+	case key.Matches(msg, m.keys.Modal.Up):
+		m.modal.MoveUp()
+	case key.Matches(msg, m.keys.Modal.Down):
+		m.modal.MoveDown()
+	case shortcut || key.Matches(msg, m.keys.Modal.Select):
+		m.mode = ViewList
+		switch value := m.modal.SelectedValue(); value {
+		case snoozeCustom:
+			// A single issue gets the regular defer date editor, prefilled
+			if t, ok := m.tasksMap[m.snoozeIDs[0]]; ok && len(m.snoozeIDs) == 1 {
+				m.selected = t
+				return m.openDateEditor(t, "defer")
+			}
+			m.editField = "snooze"
+			m.modal = ui.NewModalInput("Snooze Until", m.snoozeSubject(), "")
+			m.modal.Input.Placeholder = "YYYY-MM-DD, tomorrow, +3d, +1w, next mon"
+			m.modal.Help = "enter: snooze  empty: wake  esc: cancel"
+			m.updateDatePreview()
+			m.mode = ViewEditDate
+			return m.modal.Input.Focus()
+		case snoozeWake:
+			dates := make(map[string]string)
+			for _, id := range m.snoozeIDs {
+				dates[id] = ""
+			}
+			return m.snoozeTasks(dates)
+		default:
+			return m.snoozeBy(value)
+		}
+	case key.Matches(msg, m.keys.Modal.Cancel):
+		m.mode = ViewList
+	}
+	return nil
+}
+
+// snoozeBy defers each issue by a relative offset such as "+1w", counted
+// from its current defer date if that is still ahead, otherwise from today
+func (m *Model) snoozeBy(offset string) tea.Cmd {
+	now := time.Now()
+	dates := make(map[string]string)
+	for _, id := range m.snoozeIDs {
+		base := now
+		if t, ok := m.tasksMap[id]; ok && t.IsDeferred(now) {
+			base = *t.DeferUntil
+		}
+		date, err := models.ParseDate(offset, base)
+		if err != nil {
+			m.err = err
+			return nil
+		}
+		dates[id] = date.Format(models.DateLayout)
+	}
+	return m.snoozeTasks(dates)
+}
+
+// snoozeTasks sets the defer date of each issue; an empty date wakes it
+func (m *Model) snoozeTasks(dates map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		ids := make([]string, 0, len(dates))
+		for id := range dates {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			value := dates[id]
+			if err := m.client.Update(id, beads.UpdateOptions{DeferUntil: &value}); err != nil {
+				return snoozedMsg{err: err}
+			}
+		}
+		// Report a date only when every issue got the same one
+		until := dates[ids[0]]
+		for _, id := range ids {
+			if dates[id] != until {
+				until = "various dates"
+			}
+		}
+		return snoozedMsg{ids: ids, until: until}
+	}
+}
+
+// checkWakeups compares the issues deferred to a later day against the
+// previous load and flashes a notice for any that have become active. The
+// first load only records the deferred issues.
+func (m *Model) checkWakeups() tea.Cmd {
+	now := time.Now()
+	sleeping := make(map[string]bool)
+	var woke []string
+	for _, t := range m.tasks {
+		switch {
+		case t.IsDeferred(now):
+			sleeping[t.ID] = true
+		case m.sleepingIDs[t.ID] && t.Status != "closed":
+			woke = append(woke, t.ID)
+		}
+	}
+	first := m.sleepingIDs == nil
+	m.sleepingIDs = sleeping
+	if first || len(woke) == 0 {
+		return nil
+	}
+
+	sort.Strings(woke)
+	if len(woke) == 1 {
+		t := m.tasksMap[woke[0]]
+		m.statusMsg = fmt.Sprintf("No longer deferred: %s %s", t.ID, t.Title)
+	} else {
+		m.statusMsg = fmt.Sprintf("No longer deferred: %s", strings.Join(woke, ", "))
+	}
+	return tea.Tick(wakeFlashDuration, func(t time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
+}
+
+// wakeLabel describes when a deferred issue becomes active again
+func wakeLabel(t *models.Task, now time.Time) string {
+	days := daysBetween(dateOnly(now), dateOnly(*t.DeferUntil))
+	switch {
+	case days <= 0:
+		return "active"
+	case days == 1:
+		return "wakes tomorrow"
+	default:
+		return fmt.Sprintf("wakes in %dd", days)
+	}
+}
@@ -0,0 +1,514 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// depTypeOption describes a relationship offered by the dependency editor.
+// Normally the selected issue is the one that depends on the picked issue;
+// reverse options make the picked issue depend on the selected one.
+type depTypeOption struct {
+	value   string // modal option value
+	depType string
+	reverse bool
+	label   string // how the relationship reads from the selected issue
+}
+
+var depTypeOptions = []depTypeOption{
+	{"blocked-by", models.DepBlocks, false, "is blocked by…"},
+	{"blocks", models.DepBlocks, true, "blocks…"},
+	{"related", models.DepRelated, false, "is related to…"},
+	{"discovered-from", models.DepDiscoveredFrom, false, "was discovered from…"},
+	{"child-of", models.DepParentChild, false, "is a child of…"},
+}
+
+// depType returns the dependency type, defaulting an empty type to blocks
+func depType(d models.Dependency) string {
+	if d.Type == "" {
+		return models.DepBlocks
+	}
+	return d.Type
+}
+
+// openDependencyEditor asks for the relationship type before picking the
+// issue to link to
+func (m *Model) openDependencyEditor(task *models.Task) {
+	var options []ui.ModalOption
+	for i, opt := range depTypeOptions {
+		options = append(options, ui.ModalOption{
+			Label:    fmt.Sprintf("%s (%s)", opt.label, opt.depType),
+			Value:    opt.value,
+			Shortcut: fmt.Sprintf("%d", i+1),
+		})
+	}
+	m.modal = ui.NewModalSelect("Add Dependency", task.ID, options, "blocked-by")
+	m.mode = ViewPickDepType
+}
+
+func (m *Model) handlePickDepTypeKeys(msg tea.KeyMsg) tea.Cmd {
+	shortcut := m.modal.SelectByShortcut(msg.String())
+
+	switch {
+	case shortcut || key.Matches(msg, m.keys.Modal.Select):
+		m.mode = ViewList
+		value := m.modal.SelectedValue()
+		for _, opt := range depTypeOptions {
+			if opt.value == value && m.selected != nil {
+				return m.openDependencyTarget(m.selected, opt)
+			}
+		}
+	case key.Matches(msg, m.keys.Modal.Up):
+		m.modal.MoveUp()
+	case key.Matches(msg, m.keys.Modal.Down):
+		m.modal.MoveDown()
+	case key.Matches(msg, m.keys.Modal.Cancel):
+		m.mode = ViewList
+	}
+	return nil
+}
+
+// epicGroup returns the picker heading for an issue: its nearest epic
+// ancestor (an epic is its own group), or "" when it is not under an epic
+func (m *Model) epicGroup(t *models.Task) string {
+	seen := make(map[string]bool)
+	for current := t; current != nil && !seen[current.ID]; {
+		seen[current.ID] = true
+		if current.Type == "epic" {
+			return current.ID + " " + current.Title
+		}
+		current = m.tasksMap[current.GetParentID()]
+	}
+	return ""
+}
+
+// formatCycle renders a chain of blocking edges closed back on its start
+func formatCycle(path []string) string {
+	return strings.Join(path, " → ") + " → " + path[0]
+}
+
+// checkDependency explains why linking task to candidate with the given
+// relationship is not allowed, or returns "" if it is
+func (m *Model) checkDependency(task, candidate *models.Task, opt depTypeOption) string {
+	switch opt.depType {
+	case models.DepBlocks:
+		blocker, blocked := candidate.ID, task.ID
+		if opt.reverse {
+			blocker, blocked = task.ID, candidate.ID
+		}
+		if slices.Contains(m.depGraph.Blockers(blocked), blocker) {
+			return "already " + blocker + " blocks " + blocked
+		}
+		if path, cycle := m.depGraph.WouldCycle(blocker, blocked); cycle {
+			return "would create a cycle: " + formatCycle(path)
+		}
+	case models.DepParentChild:
+		if task.GetParentID() == candidate.ID {
+			return "already the parent"
+		}
+		if m.isDescendantOf(candidate.ID, task.ID) {
+			return candidate.ID + " is a descendant of " + task.ID
+		}
+	default:
+		for _, dep := range task.Dependencies {
+			if dep.Type == opt.depType && dep.DependsOnID == candidate.ID {
+				return "already linked"
+			}
+		}
+		if opt.depType == models.DepRelated {
+			for _, dep := range candidate.Dependencies {
+				if dep.Type == opt.depType && dep.DependsOnID == task.ID {
+					return "already linked"
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// openDependencyTarget opens a searchable picker of issues the task can be
+// linked to. Candidates are grouped by epic; those that would create a cycle
+// or duplicate a link are listed but disabled with the reason.
+func (m *Model) openDependencyTarget(task *models.Task, opt depTypeOption) tea.Cmd {
+	var options []ui.ModalOption
+	for i := range m.tasks {
+		candidate := &m.tasks[i]
+		if candidate.ID == task.ID {
+			continue
+		}
+		// Closed issues can still be referenced, but not depended on
+		if candidate.Status == "closed" && (opt.depType == models.DepBlocks || opt.depType == models.DepParentChild) {
+			continue
+		}
+		note := m.checkDependency(task, candidate, opt)
+		options = append(options, ui.ModalOption{
+			Label:    pickerLabel(candidate),
+			Value:    candidate.ID,
+			Group:    m.epicGroup(candidate),
+			Disabled: note != "",
+			Note:     note,
+		})
+	}
+	if len(options) == 0 {
+		m.statusMsg = "No available tasks to link"
+		return tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+	}
+
+	// Issues under an epic first, grouped by epic; the rest at the end
+	sort.SliceStable(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if (a.Group == "") != (b.Group == "") {
+			return b.Group == ""
+		}
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		return a.Value < b.Value
+	})
+	for i := range options {
+		if options[i].Group == "" {
+			options[i].Group = "Not in an epic"
+		}
+	}
+
+	m.depOption = opt
+	m.modal = ui.NewModalPicker("Add Dependency", task.ID+" "+opt.label, options)
+	m.modal.Help = "type to filter  ↑/↓: nav  enter: link  esc: cancel"
+	m.mode = ViewAddBlocker
+	return m.modal.Input.Focus()
+}
+
+func (m *Model) handleAddBlockerKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.pickerUp(msg):
+		m.modal.MoveUp()
+	case m.pickerDown(msg):
+		m.modal.MoveDown()
+	case inputKey(msg, m.keys.Modal.Select):
+		opt, ok := m.modal.SelectedOption()
+		if !ok || m.selected == nil {
+			return nil
+		}
+		if opt.Disabled {
+			// Keep the picker open; the note explains why
+			m.statusMsg = "Can't link " + opt.Value + ": " + opt.Note
+			return tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+				return clearStatusMsg{}
+			})
+		}
+		issueID, dependsOnID := m.selected.ID, opt.Value
+		if m.depOption.reverse {
+			issueID, dependsOnID = dependsOnID, issueID
+		}
+		depType := m.depOption.depType
+		m.mode = ViewList
+		return func() tea.Msg {
+			err := m.client.AddDependency(issueID, dependsOnID, depType)
+			return blockerAddedMsg{depType: depType, err: err}
+		}
+	case key.Matches(msg, m.keys.Modal.Cancel):
+		m.mode = ViewList
+	}
+	return nil
+}
+
+// reverseDepPrefix marks remove-dependency options where the other issue
+// is the one that depends on the selected issue
+const reverseDepPrefix = "^"
+
+// openRemoveDependency lists the links of the task for removal: everything
+// it depends on, plus the issues it blocks
+func (m *Model) openRemoveDependency(task *models.Task) tea.Cmd {
+	var options []ui.ModalOption
+	seen := make(map[string]bool)
+	add := func(value, id, relation string) {
+		if seen[value] {
+			return
+		}
+		seen[value] = true
+		label := fmt.Sprintf("[%s] %s", relation, id)
+		if linked, ok := m.tasksMap[id]; ok {
+			label += " - " + linked.Title
+		}
+		options = append(options, ui.ModalOption{Label: ui.Truncate(label, 50), Value: value})
+	}
+	for _, dep := range task.Dependencies {
+		relation := depType(dep)
+		if relation == models.DepBlocks {
+			relation = "blocked by"
+		}
+		add(dep.DependsOnID, dep.DependsOnID, relation)
+	}
+	for _, id := range task.BlockedBy {
+		add(id, id, "blocked by")
+	}
+	for _, id := range m.depGraph.Dependents(task.ID) {
+		add(reverseDepPrefix+id, id, "blocks")
+	}
+
+	if len(options) == 0 {
+		m.statusMsg = "No dependencies to remove"
+		return tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+	}
+	m.modal = ui.NewModalSelect("Remove Dependency", task.ID, options, "")
+	m.mode = ViewRemoveBlocker
+	return nil
+}
+
+// linkGroup is a titled list of linked issues shown in the detail view.
+// Every entry can be focused with tab and opened with enter.
+type linkGroup struct {
+	label string
+	ids   []string
+}
+
+// directChildren returns the IDs of t's children, found via explicit
+// parent-child dependencies first and the ID naming convention second
+func (m *Model) directChildren(t *models.Task) []string {
+	childIDs := make(map[string]bool)
+	var children []string
+	for _, task := range m.tasks {
+		for _, dep := range task.Dependencies {
+			if dep.IsParentChild() && dep.DependsOnID == t.ID && !childIDs[task.ID] {
+				childIDs[task.ID] = true
+				children = append(children, task.ID)
+			}
+		}
+	}
+	for _, task := range m.tasks {
+		if !childIDs[task.ID] && models.IsDirectChildOf(task.ID, t.ID) {
+			childIDs[task.ID] = true
+			children = append(children, task.ID)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// linkGroups collects the issues linked to t, grouped by relationship
+func (m *Model) linkGroups(t *models.Task) []linkGroup {
+	groups := []linkGroup{
+		{label: "Blocked by:", ids: m.depGraph.Blockers(t.ID)},
+		{label: "Blocks:", ids: m.depGraph.Dependents(t.ID)},
+	}
+
+	related := make(map[string]bool)
+	var discoveredFrom, discovered []string
+	other := make(map[string][]string)
+	for _, dep := range t.Dependencies {
+		switch dep.Type {
+		case models.DepRelated:
+			related[dep.DependsOnID] = true
+		case models.DepDiscoveredFrom:
+			discoveredFrom = append(discoveredFrom, dep.DependsOnID)
+		case "", models.DepBlocks:
+		default:
+			if !dep.IsParentChild() {
+				other[dep.Type] = append(other[dep.Type], dep.DependsOnID)
+			}
+		}
+	}
+	// Incoming links: related is symmetric, discovered-from is shown from
+	// both ends
+	for _, task := range m.tasks {
+		for _, dep := range task.Dependencies {
+			if dep.DependsOnID != t.ID {
+				continue
+			}
+			switch dep.Type {
+			case models.DepRelated:
+				related[task.ID] = true
+			case models.DepDiscoveredFrom:
+				discovered = append(discovered, task.ID)
+			}
+		}
+	}
+
+	var relatedIDs []string
+	for id := range related {
+		relatedIDs = append(relatedIDs, id)
+	}
+	sort.Strings(relatedIDs)
+	sort.Strings(discoveredFrom)
+	sort.Strings(discovered)
+
+	groups = append(groups,
+		linkGroup{label: "Related:", ids: relatedIDs},
+		linkGroup{label: "Discovered from:", ids: discoveredFrom},
+		linkGroup{label: "Discovered here:", ids: discovered},
+	)
+
+	var otherTypes []string
+	for depType := range other {
+		otherTypes = append(otherTypes, depType)
+	}
+	sort.Strings(otherTypes)
+	for _, depType := range otherTypes {
+		groups = append(groups, linkGroup{label: depType + ":", ids: other[depType]})
+	}
+
+	// Hierarchy, using explicit parent-child dependencies first and the ID
+	// naming convention as a fallback
+	var parent []string
+	if parentID := t.GetParentID(); parentID != "" {
+		parent = []string{parentID}
+	}
+	groups = append(groups,
+		linkGroup{label: "Parent:", ids: parent},
+		linkGroup{label: "Children:", ids: m.directChildren(t)},
+	)
+	return groups
+}
+
+// detailLinks returns the IDs that can be focused in the detail view, in
+// display order
+func (m *Model) detailLinks(t *models.Task) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, g := range m.linkGroups(t) {
+		for _, id := range g.ids {
+			// An issue listed under several relationships is focused once
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// cycleDetailLink moves the detail view's link focus by delta
+func (m *Model) cycleDetailLink(delta int) {
+	if m.selected == nil {
+		return
+	}
+	links := m.detailLinks(m.selected)
+	if len(links) == 0 {
+		m.detailLinkID = ""
+		return
+	}
+	idx := -1
+	for i, id := range links {
+		if id == m.detailLinkID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		if delta > 0 {
+			idx = 0
+		} else {
+			idx = len(links) - 1
+		}
+	} else {
+		idx = (idx + delta + len(links)) % len(links)
+	}
+	m.detailLinkID = links[idx]
+
+	// Scroll the focused link into view
+	m.updateDetailContent()
+	if m.detailLinkLine < m.detail.YOffset || m.detailLinkLine >= m.detail.YOffset+m.detail.Height {
+		m.detail.SetYOffset(m.detailLinkLine - m.detail.Height/2)
+	}
+}
+
+// focusedDetailLink returns the focused link if it still belongs to the
+// selected issue
+func (m *Model) focusedDetailLink() string {
+	if m.selected == nil || m.detailLinkID == "" {
+		return ""
+	}
+	for _, id := range m.detailLinks(m.selected) {
+		if id == m.detailLinkID {
+			return id
+		}
+	}
+	return ""
+}
+
+// navigateToIssue shows another issue in the detail view, recording the
+// current one in the back history
+func (m *Model) navigateToIssue(id string) tea.Cmd {
+	if _, ok := m.tasksMap[id]; ok && m.selected != nil {
+		m.detailBack = append(m.detailBack, m.selected.ID)
+		m.detailForward = nil
+	}
+	return m.showIssue(id)
+}
+
+// historyBack returns to the previously viewed issue
+func (m *Model) historyBack() tea.Cmd {
+	if len(m.detailBack) == 0 {
+		return nil
+	}
+	id := m.detailBack[len(m.detailBack)-1]
+	m.detailBack = m.detailBack[:len(m.detailBack)-1]
+	if m.selected != nil {
+		m.detailForward = append(m.detailForward, m.selected.ID)
+	}
+	return m.showIssue(id)
+}
+
+// historyForward revisits an issue left with historyBack
+func (m *Model) historyForward() tea.Cmd {
+	if len(m.detailForward) == 0 {
+		return nil
+	}
+	id := m.detailForward[len(m.detailForward)-1]
+	m.detailForward = m.detailForward[:len(m.detailForward)-1]
+	if m.selected != nil {
+		m.detailBack = append(m.detailBack, m.selected.ID)
+	}
+	return m.showIssue(id)
+}
+
+// resetDetailHistory forgets the back/forward history when the detail view
+// is closed
+func (m *Model) resetDetailHistory() {
+	m.detailBack = nil
+	m.detailForward = nil
+	m.detailLinkID = ""
+}
+
+// closeDetail leaves the detail view for the view it was opened from
+func (m *Model) closeDetail() {
+	m.resetDetailHistory()
+	switch m.previousMode {
+	case ViewBoard, ViewTimeline, ViewCalendar:
+		m.mode = m.previousMode
+	default:
+		m.mode = ViewList
+	}
+	m.previousMode = ViewList // Reset
+}
+
+// showIssue switches the detail view to another issue
+func (m *Model) showIssue(id string) tea.Cmd {
+	t, ok := m.tasksMap[id]
+	if !ok {
+		m.statusMsg = id + " is not loaded"
+		return tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+	}
+	if !m.revealTask(id) {
+		m.selected = t
+	}
+	m.detailLinkID = ""
+	m.comments = nil
+	m.detail.GotoTop()
+	return m.loadComments(id)
+}
@@ -0,0 +1,287 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/beads"
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// openLabelEditor opens the label picker for a task. Known labels from all
+// loaded tasks are offered for autocompletion; typing a new name offers to
+// create it.
+func (m *Model) openLabelEditor(task *models.Task) tea.Cmd {
+	m.labelDraft = make(map[string]bool)
+	for _, l := range task.Labels {
+		m.labelDraft[l] = true
+	}
+	m.modal = ui.NewModalPicker("Edit Labels", task.ID, nil)
+	m.modal.Help = "enter: add/remove  tab: complete  ↑/↓: nav  esc: done"
+	m.refreshLabelOptions()
+	m.mode = ViewEditLabels
+	return m.modal.Input.Focus()
+}
+
+// knownLabels returns every label used by a loaded task or the draft, sorted
+func (m *Model) knownLabels() []string {
+	seen := make(map[string]bool)
+	var labels []string
+	add := func(l string) {
+		if l != "" && !seen[l] {
+			seen[l] = true
+			labels = append(labels, l)
+		}
+	}
+	for _, t := range m.tasks {
+		for _, l := range t.Labels {
+			add(l)
+		}
+	}
+	for l := range m.labelDraft {
+		add(l)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// refreshLabelOptions rebuilds the label picker options from the draft and
+// the current query
+func (m *Model) refreshLabelOptions() {
+	query := strings.TrimSpace(m.modal.InputValue())
+	var options []ui.ModalOption
+	exact := false
+	for _, l := range m.knownLabels() {
+		mark := "  "
+		if m.labelDraft[l] {
+			mark = "✓ "
+		}
+		options = append(options, ui.ModalOption{Label: mark + l, Value: l})
+		if l == query {
+			exact = true
+		}
+	}
+	if query != "" && !exact {
+		options = append(options, ui.ModalOption{
+			Label: fmt.Sprintf("+ new label %q", query),
+			Value: query,
+		})
+	}
+	m.modal.Options = options
+	m.modal.ApplyFilter()
+}
+
+func (m *Model) handleEditLabelsKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.pickerUp(msg):
+		m.modal.MoveUp()
+	case m.pickerDown(msg):
+		m.modal.MoveDown()
+	case key.Matches(msg, m.keys.Form.Tab):
+		// Autocomplete the query to the highlighted label
+		if value := m.modal.SelectedValue(); value != "" {
+			m.modal.Input.SetValue(value)
+			m.modal.Input.CursorEnd()
+			m.refreshLabelOptions()
+		}
+	case key.Matches(msg, m.keys.Form.Submit):
+		label := m.modal.SelectedValue()
+		if label == "" || m.selected == nil {
+			return nil
+		}
+		taskID := m.selected.ID
+		var opts beads.UpdateOptions
+		if m.labelDraft[label] {
+			delete(m.labelDraft, label)
+			opts.RemoveLabels = []string{label}
+		} else {
+			m.labelDraft[label] = true
+			opts.AddLabels = []string{label}
+		}
+		m.modal.Input.SetValue("")
+		m.refreshLabelOptions()
+		return func() tea.Msg {
+			err := m.client.Update(taskID, opts)
+			return taskUpdatedMsg{err: err}
+		}
+	case key.Matches(msg, m.keys.Form.Cancel):
+		m.mode = ViewList
+	}
+	return nil
+}
+
+// openAssigneePicker opens a picker of people seen on loaded tasks
+func (m *Model) openAssigneePicker(task *models.Task) tea.Cmd {
+	m.modal = ui.NewModalPicker("Assign", task.ID, nil)
+	m.refreshAssigneeOptions()
+	m.mode = ViewEditAssignee
+	return m.modal.Input.Focus()
+}
+
+// knownPeople returns the distinct assignees, owners and creators, sorted
+func (m *Model) knownPeople() []string {
+	seen := make(map[string]bool)
+	var people []string
+	for _, t := range m.tasks {
+		for _, p := range []string{t.Assignee, t.Owner, t.CreatedBy} {
+			if p != "" && !seen[p] {
+				seen[p] = true
+				people = append(people, p)
+			}
+		}
+	}
+	sort.Strings(people)
+	return people
+}
+
+// refreshAssigneeOptions rebuilds the assignee picker options for the
+// current query
+func (m *Model) refreshAssigneeOptions() {
+	query := strings.TrimSpace(m.modal.InputValue())
+	current := ""
+	if m.selected != nil {
+		current = m.selected.Assignee
+	}
+	var options []ui.ModalOption
+	exact := false
+	for _, p := range m.knownPeople() {
+		label := p
+		if p == current {
+			label += " (current)"
+		}
+		options = append(options, ui.ModalOption{Label: label, Value: p})
+		if p == query {
+			exact = true
+		}
+	}
+	if query != "" && !exact {
+		options = append(options, ui.ModalOption{
+			Label: fmt.Sprintf("+ assign to %q", query),
+			Value: query,
+		})
+	}
+	m.modal.Options = options
+	m.modal.ApplyFilter()
+}
+
+func (m *Model) handleEditAssigneeKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.pickerUp(msg):
+		m.modal.MoveUp()
+	case m.pickerDown(msg):
+		m.modal.MoveDown()
+	case key.Matches(msg, m.keys.Form.Submit):
+		assignee := m.modal.SelectedValue()
+		m.mode = ViewList
+		if assignee == "" || m.selected == nil {
+			return nil
+		}
+		taskID := m.selected.ID
+		return func() tea.Msg {
+			err := m.client.Update(taskID, beads.UpdateOptions{
+				Assignee: assignee,
+			})
+			return taskUpdatedMsg{err: err}
+		}
+	case key.Matches(msg, m.keys.Form.Cancel):
+		m.mode = ViewList
+	}
+	return nil
+}
+
+// openDateEditor opens a date input for the due ("due") or defer ("defer")
+// date of a task. Relative input such as "+3d" or "next fri" is accepted.
+func (m *Model) openDateEditor(task *models.Task, field string) tea.Cmd {
+	title := "Due Date"
+	current := task.DueDate
+	if field == "defer" {
+		title = "Defer Until"
+		current = task.DeferUntil
+	}
+	value := ""
+	if current != nil {
+		value = current.Format(models.DateLayout)
+	}
+	m.editField = field
+	m.modal = ui.NewModalInput(title, task.ID, value)
+	m.modal.Input.Placeholder = "YYYY-MM-DD, today, +3d, +1w, next fri"
+	m.modal.Help = "enter: save  empty: clear  esc: cancel"
+	m.updateDatePreview()
+	m.mode = ViewEditDate
+	return m.modal.Input.Focus()
+}
+
+// updateDatePreview shows how the current date input will be interpreted
+func (m *Model) updateDatePreview() {
+	var subtitle string
+	switch {
+	case m.editField == "snooze":
+		// Snoozing marked issues needs no selection
+		subtitle = m.snoozeSubject()
+	case m.selected != nil:
+		subtitle = m.selected.ID
+	default:
+		return
+	}
+	input := strings.TrimSpace(m.modal.InputValue())
+	if input == "" {
+		subtitle += "  (clear)"
+	} else if date, err := models.ParseDate(input, time.Now()); err != nil {
+		subtitle += "  (invalid date)"
+	} else {
+		subtitle += "  → " + date.Format("Mon "+models.DateLayout)
+	}
+	m.modal.Subtitle = subtitle
+}
+
+func (m *Model) handleEditDateKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Form.Submit):
+		// Snoozing marked issues needs no selection
+		snooze := m.editField == "snooze"
+		if snooze && len(m.snoozeIDs) == 0 || !snooze && m.selected == nil {
+			m.mode = ViewList
+			return nil
+		}
+		input := strings.TrimSpace(m.modal.InputValue())
+		value := ""
+		if input != "" {
+			date, err := models.ParseDate(input, time.Now())
+			if err != nil {
+				// Keep the editor open; the subtitle shows the problem
+				return nil
+			}
+			value = date.Format(models.DateLayout)
+		}
+		if snooze {
+			m.mode = ViewList
+			dates := make(map[string]string)
+			for _, id := range m.snoozeIDs {
+				dates[id] = value
+			}
+			return m.snoozeTasks(dates)
+		}
+		taskID := m.selected.ID
+		field := m.editField
+		m.mode = ViewList
+		return func() tea.Msg {
+			var opts beads.UpdateOptions
+			if field == "defer" {
+				opts.DeferUntil = &value
+			} else {
+				opts.DueDate = &value
+			}
+			err := m.client.Update(taskID, opts)
+			return taskUpdatedMsg{err: err}
+		}
+	case key.Matches(msg, m.keys.Form.Cancel):
+		m.mode = ViewList
+	}
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/josebiro/bb/internal/beads"
 )
 
+// formDescHeight is the number of lines shown for the form description
+const formDescHeight = 6
+
 func (m *Model) updateForm(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 
@@ -18,6 +21,10 @@ func (m *Model) updateForm(msg tea.Msg) tea.Cmd {
 		m.formTitle, cmd = m.formTitle.Update(msg)
 		cmds = append(cmds, cmd)
 	case 1:
+		// Enter submits the form rather than inserting a newline
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			break
+		}
 		var cmd tea.Cmd
 		m.formDesc, cmd = m.formDesc.Update(msg)
 		cmds = append(cmds, cmd)
@@ -65,6 +72,9 @@ func (m *Model) resetForm() {
 	m.formPriority = 2
 	m.formType = "feature"
 	m.formFocus = 0
+	m.formParentID = ""
+	m.formLabels = nil
+	m.formTemplate = nil
 	m.updateFormFocus()
 }
 
@@ -96,13 +106,36 @@ func (m *Model) submitForm() tea.Cmd {
 		}
 	}
 
+	parentID := m.formParentID
+	tmpl := m.formTemplate
+	opts := beads.CreateOptions{
+		Title:       title,
+		Description: m.formDesc.Value(),
+		Type:        m.formType,
+		Priority:    m.formPriority,
+		Labels:      m.formLabels,
+		Parent:      parentID,
+	}
+
+	// Templates with children are created step by step with progress
+	if tmpl != nil && len(tmpl.Children) > 0 {
+		if m.templateRun != nil {
+			m.err = fmt.Errorf("a template is already being created")
+			return nil
+		}
+		run, err := newTemplateRun(tmpl, opts)
+		if err != nil {
+			m.err = err
+			return nil
+		}
+		m.templateRun = run
+		m.templateParentID = parentID
+		m.mode = ViewList
+		return m.runTemplateStep(run)
+	}
+
 	return func() tea.Msg {
-		task, err := m.client.Create(beads.CreateOptions{
-			Title:       title,
-			Description: m.formDesc.Value(),
-			Type:        m.formType,
-			Priority:    m.formPriority,
-		})
-		return taskCreatedMsg{task: task, err: err}
+		task, err := m.client.Create(opts)
+		return taskCreatedMsg{task: task, parentID: parentID, err: err}
 	}
 }
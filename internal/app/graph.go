@@ -0,0 +1,380 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+const (
+	graphDefaultDepth = 2
+	graphMaxDepth     = 6
+	graphGutterWidth  = 5 // width of the edge-drawing column between node columns
+	graphMinColWidth  = 18
+	graphMaxColWidth  = 40
+)
+
+// graphLayout places the issues around the graph root into columns:
+// blockers on the left, the root in the middle, dependents on the right.
+type graphLayout struct {
+	columns [][]string
+	rootCol int
+}
+
+// find returns the column and row of id, or -1, -1 if it is not placed
+func (l graphLayout) find(id string) (int, int) {
+	for c, col := range l.columns {
+		for r, nodeID := range col {
+			if nodeID == id {
+				return c, r
+			}
+		}
+	}
+	return -1, -1
+}
+
+// openGraph shows the dependency graph centered on the given task
+func (m *Model) openGraph(task *models.Task) {
+	m.graphRootID = task.ID
+	m.graphFocusID = task.ID
+	if m.graphDepth == 0 {
+		m.graphDepth = graphDefaultDepth
+	}
+	m.mode = ViewGraph
+}
+
+func (m *Model) graphLayout() graphLayout {
+	upstream, downstream := m.depGraph.Levels(m.graphRootID, m.graphDepth)
+	var l graphLayout
+	for i := len(upstream) - 1; i >= 0; i-- {
+		l.columns = append(l.columns, upstream[i])
+	}
+	l.rootCol = len(l.columns)
+	l.columns = append(l.columns, []string{m.graphRootID})
+	l.columns = append(l.columns, downstream...)
+	return l
+}
+
+// graphNeighbor picks the node to jump to when following edges from the
+// focused node. Candidates in the nearest column in the given direction win;
+// within that column the one closest to the current row is chosen.
+func graphNeighbor(l graphLayout, col, row int, candidates []string, dir int) string {
+	best, bestCol, bestDist := "", -1, 0
+	for _, id := range candidates {
+		c, r := l.find(id)
+		if c < 0 || (c-col)*dir <= 0 {
+			continue
+		}
+		dist := r - row
+		if dist < 0 {
+			dist = -dist
+		}
+		colDist := (c - col) * dir
+		if best == "" || colDist < (bestCol-col)*dir || (c == bestCol && dist < bestDist) {
+			best, bestCol, bestDist = id, c, dist
+		}
+	}
+	return best
+}
+
+func (m *Model) handleGraphKeys(msg tea.KeyMsg) tea.Cmd {
+	l := m.graphLayout()
+	col, row := l.find(m.graphFocusID)
+	if col < 0 {
+		// Focused node fell out of the graph (e.g. after a reload)
+		m.graphFocusID = m.graphRootID
+		col, row = l.rootCol, 0
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.List.PrevView): // h/left - follow an edge to a blocker
+		if id := graphNeighbor(l, col, row, m.depGraph.Blockers(m.graphFocusID), -1); id != "" {
+			m.graphFocusID = id
+		}
+
+	case key.Matches(msg, m.keys.List.NextView): // l/right - follow an edge to a dependent
+		if id := graphNeighbor(l, col, row, m.depGraph.Dependents(m.graphFocusID), 1); id != "" {
+			m.graphFocusID = id
+		}
+
+	case key.Matches(msg, m.keys.List.Up):
+		if row > 0 {
+			m.graphFocusID = l.columns[col][row-1]
+		}
+
+	case key.Matches(msg, m.keys.List.Down):
+		if row < len(l.columns[col])-1 {
+			m.graphFocusID = l.columns[col][row+1]
+		}
+
+	case key.Matches(msg, m.keys.List.Select): // enter - re-center on the focused node
+		m.graphRootID = m.graphFocusID
+		if t, ok := m.tasksMap[m.graphFocusID]; ok {
+			m.selected = t
+			m.revealTask(t.ID)
+		}
+
+	case msg.String() == "+" || msg.String() == "=":
+		if m.graphDepth < graphMaxDepth {
+			m.graphDepth++
+		}
+
+	case msg.String() == "-":
+		if m.graphDepth > 1 {
+			m.graphDepth--
+			if c, _ := m.graphLayout().find(m.graphFocusID); c < 0 {
+				m.graphFocusID = m.graphRootID
+			}
+		}
+
+	case key.Matches(msg, m.keys.List.Graph), key.Matches(msg, m.keys.List.Cancel):
+		m.mode = ViewList
+
+	case key.Matches(msg, m.keys.List.Help):
+		m.mode = ViewHelp
+	}
+	return nil
+}
+
+// graphGutter draws the edges between two adjacent columns. Nodes sit on
+// every other line, so node i of a column is at line 2*i. Edges leave the
+// left node, run vertically in the middle of the gutter and enter the right
+// node with an arrow head.
+func (m *Model) graphGutter(left, right []string, lines int) []string {
+	rightRow := make(map[string]int, len(right))
+	for i, id := range right {
+		rightRow[id] = i
+	}
+
+	type cell struct{ west, east, north, south bool }
+	mid := make([]cell, lines)
+	outgoing := make([]bool, lines)
+	incoming := make([]bool, lines)
+	for i, id := range left {
+		for _, dep := range m.depGraph.Dependents(id) {
+			j, ok := rightRow[dep]
+			if !ok {
+				continue
+			}
+			from, to := 2*i, 2*j
+			if from >= lines || to >= lines {
+				continue
+			}
+			outgoing[from] = true
+			incoming[to] = true
+			mid[from].west = true
+			mid[to].east = true
+			lo, hi := from, to
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			for y := lo; y <= hi; y++ {
+				if y > lo {
+					mid[y].north = true
+				}
+				if y < hi {
+					mid[y].south = true
+				}
+			}
+		}
+	}
+
+	junctions := map[cell]string{
+		{west: true, east: true}:                           "─",
+		{north: true, south: true}:                         "│",
+		{west: true, south: true}:                          "┐",
+		{west: true, north: true}:                          "┘",
+		{east: true, south: true}:                          "┌",
+		{east: true, north: true}:                          "└",
+		{west: true, east: true, south: true}:              "┬",
+		{west: true, east: true, north: true}:              "┴",
+		{north: true, south: true, east: true}:             "├",
+		{north: true, south: true, west: true}:             "┤",
+		{west: true, east: true, north: true, south: true}: "┼",
+	}
+
+	edgeStyle := lipgloss.NewStyle().Foreground(ui.ColorBorder)
+	out := make([]string, lines)
+	for y := 0; y < lines; y++ {
+		var b strings.Builder
+		if outgoing[y] {
+			b.WriteString("──")
+		} else {
+			b.WriteString("  ")
+		}
+		if j, ok := junctions[mid[y]]; ok {
+			b.WriteString(j)
+		} else {
+			b.WriteString(" ")
+		}
+		if incoming[y] {
+			b.WriteString("─▶")
+		} else {
+			b.WriteString("  ")
+		}
+		out[y] = edgeStyle.Render(b.String())
+	}
+	return out
+}
+
+// graphNode renders a single node line padded to width
+func (m *Model) graphNode(id string, width int, focused, root, linked bool) string {
+	marker := " "
+	if linked {
+		marker = "•"
+	}
+	if root {
+		marker = "◆"
+	}
+
+	t, ok := m.tasksMap[id]
+	if !ok {
+		text := ui.Truncate(marker+" "+id+" (not loaded)", width)
+		return padText(ui.HelpDescStyle.Render(text), width)
+	}
+
+	label := fmt.Sprintf("%s %s %s %s %s", marker, t.StatusIcon(), t.ID, t.PriorityString(), t.Title)
+	label = ui.Truncate(label, width)
+	style := ui.StatusStyle(t.Status)
+	if root {
+		style = style.Bold(true)
+	}
+	if focused {
+		style = style.Background(ui.ColorHighlight)
+		return style.Render(padText(label, width))
+	}
+	return padText(style.Render(label), width)
+}
+
+func (m Model) viewGraph() string {
+	var b strings.Builder
+
+	l := m.graphLayout()
+	focusCol, focusRow := l.find(m.graphFocusID)
+	if focusCol < 0 {
+		focusCol, focusRow = l.rootCol, 0
+	}
+
+	title := fmt.Sprintf("DEPENDENCY GRAPH  %s  depth %d", m.graphRootID, m.graphDepth)
+	b.WriteString(ui.TitleStyle.Render(title) + "\n")
+
+	// Columns that fit, kept around the focused column
+	colWidth := graphMaxColWidth
+	visibleCols := (m.width + graphGutterWidth) / (colWidth + graphGutterWidth)
+	if visibleCols < len(l.columns) {
+		colWidth = graphMinColWidth
+		visibleCols = (m.width + graphGutterWidth) / (colWidth + graphGutterWidth)
+	}
+	if visibleCols < 1 {
+		visibleCols = 1
+	}
+	if visibleCols > len(l.columns) {
+		visibleCols = len(l.columns)
+	}
+	if fit := (m.width+graphGutterWidth)/visibleCols - graphGutterWidth; fit > colWidth && fit <= graphMaxColWidth {
+		colWidth = fit
+	}
+	firstCol := focusCol - visibleCols/2
+	if firstCol > len(l.columns)-visibleCols {
+		firstCol = len(l.columns) - visibleCols
+	}
+	if firstCol < 0 {
+		firstCol = 0
+	}
+
+	// Vertical scroll keeps the focused node on screen; all columns share
+	// the offset so edges stay aligned
+	bodyHeight := m.height - 5
+	if bodyHeight < 4 {
+		bodyHeight = 4
+	}
+	tallest := 0
+	for _, col := range l.columns {
+		if len(col) > tallest {
+			tallest = len(col)
+		}
+	}
+	lines := 2*tallest - 1
+	offset := 0
+	if 2*focusRow >= bodyHeight {
+		offset = 2*focusRow - bodyHeight/2
+	}
+
+	linked := make(map[string]bool)
+	for _, id := range m.depGraph.Blockers(m.graphFocusID) {
+		linked[id] = true
+	}
+	for _, id := range m.depGraph.Dependents(m.graphFocusID) {
+		linked[id] = true
+	}
+
+	var blocks []string
+	for c := firstCol; c < firstCol+visibleCols; c++ {
+		if c > firstCol {
+			gutter := m.graphGutter(l.columns[c-1], l.columns[c], lines)
+			blocks = append(blocks, strings.Join(graphWindow(gutter, offset, bodyHeight, graphGutterWidth), "\n"))
+		}
+		colLines := make([]string, lines)
+		for y := range colLines {
+			colLines[y] = strings.Repeat(" ", colWidth)
+		}
+		for r, id := range l.columns[c] {
+			colLines[2*r] = m.graphNode(id, colWidth, c == focusCol && r == focusRow, id == m.graphRootID, linked[id])
+		}
+		blocks = append(blocks, strings.Join(graphWindow(colLines, offset, bodyHeight, colWidth), "\n"))
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, blocks...))
+	b.WriteString("\n")
+
+	// Summary of the focused node's edges
+	var info string
+	if t, ok := m.tasksMap[m.graphFocusID]; ok {
+		info = t.ID + " " + t.Title + " [" + t.Status + "]"
+	} else {
+		info = m.graphFocusID
+	}
+	if ids := m.depGraph.Blockers(m.graphFocusID); len(ids) > 0 {
+		info += "  ← blocked by " + strings.Join(ids, ", ")
+	}
+	if ids := m.depGraph.Dependents(m.graphFocusID); len(ids) > 0 {
+		info += "  → blocks " + strings.Join(ids, ", ")
+	}
+	b.WriteString(ui.HelpDescStyle.Render(ui.Truncate(info, m.width)))
+	b.WriteString("\n")
+
+	hidden := ""
+	if visibleCols < len(l.columns) {
+		hidden = fmt.Sprintf("  [columns %d-%d of %d]", firstCol+1, firstCol+visibleCols, len(l.columns))
+	}
+	b.WriteString(ui.HelpBarStyle.Render("h/l:follow edge  j/k:move  enter:center  +/-:depth  M/esc:back" + hidden))
+
+	return b.String()
+}
+
+// graphWindow returns exactly height lines of the given column starting at
+// offset, padding with blank lines of the given width
+func graphWindow(lines []string, offset, height, width int) []string {
+	out := make([]string, 0, height)
+	for y := offset; y < offset+height; y++ {
+		if y < len(lines) {
+			out = append(out, lines[y])
+		} else {
+			out = append(out, strings.Repeat(" ", width))
+		}
+	}
+	return out
+}
+
+// padText right-pads s with spaces to width cells
+func padText(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
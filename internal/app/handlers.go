@@ -3,9 +3,7 @@ package app
 import (
 	"bytes"
 	"fmt"
-	"os/exec"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/atotto/clipboard"
@@ -139,7 +137,7 @@ func (m *Model) calculatePanelBounds() map[PanelFocus]panelBounds {
 		panelWidth = m.width
 	}
 
-	currentY := 0
+	currentY := m.bannerHeight()
 
 	// In Progress panel (if visible)
 	if m.isInProgressVisible() {
@@ -252,13 +250,8 @@ func (m *Model) handleDetailMouse(msg tea.MouseMsg) tea.Cmd {
 	}
 	switch msg.Button {
 	case tea.MouseButtonLeft:
-		// Click anywhere to go back to where we came from (board or list)
-		if m.previousMode == ViewBoard {
-			m.mode = ViewBoard
-		} else {
-			m.mode = ViewList
-		}
-		m.previousMode = ViewList // Reset
+		// Click anywhere to go back to where we came from
+		m.closeDetail()
 	case tea.MouseButtonWheelUp:
 		m.detail.LineUp(3)
 	case tea.MouseButtonWheelDown:
@@ -533,6 +526,32 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return m.handleRemoveBlockerKeys(msg)
 	case ViewEditText:
 		return m.handleTextEditKeys(msg)
+	case ViewMoveParent:
+		return m.handleMoveParentKeys(msg)
+	case ViewPickTemplate:
+		return m.handlePickTemplateKeys(msg)
+	case ViewEditLabels:
+		return m.handleEditLabelsKeys(msg)
+	case ViewEditAssignee:
+		return m.handleEditAssigneeKeys(msg)
+	case ViewEditDate:
+		return m.handleEditDateKeys(msg)
+	case ViewGraph:
+		return m.handleGraphKeys(msg)
+	case ViewReport:
+		return m.handleReportKeys(msg)
+	case ViewCommandOutput:
+		return m.handleCommandOutputKeys(msg)
+	case ViewCommandPrompt:
+		return m.handleCommandPromptKeys(msg)
+	case ViewPickDepType:
+		return m.handlePickDepTypeKeys(msg)
+	case ViewTimeline:
+		return m.handleTimelineKeys(msg)
+	case ViewCalendar:
+		return m.handleCalendarKeys(msg)
+	case ViewSnooze:
+		return m.handleSnoozeKeys(msg)
 	}
 	return nil
 }
@@ -541,24 +560,24 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 	// First, let the focused panel handle navigation keys
 	switch m.focusedPanel {
 	case FocusInProgress:
-		if m.inProgressPanel.HandleKey(msg, m.keys) {
+		if m.inProgressPanel.HandleKey(msg, m.keys.List) {
 			m.selected = m.getSelectedTask()
 			return nil
 		}
 	case FocusOpen:
-		if m.openPanel.HandleKey(msg, m.keys) {
+		if m.openPanel.HandleKey(msg, m.keys.List) {
 			m.selected = m.getSelectedTask()
 			return nil
 		}
 	case FocusClosed:
-		if m.closedPanel.HandleKey(msg, m.keys) {
+		if m.closedPanel.HandleKey(msg, m.keys.List) {
 			m.selected = m.getSelectedTask()
 			return nil
 		}
 	}
 
 	switch {
-	case key.Matches(msg, m.keys.Select):
+	case key.Matches(msg, m.keys.List.Select):
 		if task := m.getSelectedTask(); task != nil {
 			m.selected = task
 			m.comments = nil // Clear old comments
@@ -568,7 +587,7 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.loadComments(task.ID)
 		}
 
-	case key.Matches(msg, m.keys.ToggleExpand):
+	case key.Matches(msg, m.keys.List.ToggleExpand):
 		if task := m.getSelectedTask(); task != nil {
 			// Toggle collapsed state for this node
 			if m.collapsedNodes[task.ID] {
@@ -582,13 +601,15 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			m.selected = m.getSelectedTask()
 		}
 
-	case key.Matches(msg, m.keys.Add):
-		m.resetForm()
-		m.editing = false
-		m.mode = ViewForm
-		m.formTitle.Focus()
+	case key.Matches(msg, m.keys.List.Add):
+		m.openCreateForm("")
+
+	case key.Matches(msg, m.keys.List.AddChild):
+		if task := m.getSelectedTask(); task != nil {
+			m.openCreateForm(task.ID)
+		}
 
-	case key.Matches(msg, m.keys.Delete):
+	case key.Matches(msg, m.keys.List.Delete):
 		if task := m.getSelectedTask(); task != nil {
 			m.confirmMsg = fmt.Sprintf("Delete task %s?", task.ID)
 			taskID := task.ID
@@ -601,28 +622,28 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			m.mode = ViewConfirm
 		}
 
-	case key.Matches(msg, m.keys.PrevView):
+	case key.Matches(msg, m.keys.List.PrevView):
 		m.cyclePanelFocus(-1)
 
-	case key.Matches(msg, m.keys.NextView):
+	case key.Matches(msg, m.keys.List.NextView):
 		m.cyclePanelFocus(1)
 
-	case key.Matches(msg, m.keys.Refresh):
+	case key.Matches(msg, m.keys.List.Refresh):
 		if !m.loading {
 			m.loading = true
 			return m.loadTasks()
 		}
 
-	case key.Matches(msg, m.keys.Help):
+	case key.Matches(msg, m.keys.List.Help):
 		m.mode = ViewHelp
 
-	case key.Matches(msg, m.keys.EditTitle):
+	case key.Matches(msg, m.keys.List.EditTitle):
 		if task := m.getSelectedTask(); task != nil {
 			m.modal = ui.NewModalInput("Edit Title", task.ID, task.Title)
 			m.mode = ViewEditTitle
 		}
 
-	case key.Matches(msg, m.keys.EditStatus):
+	case key.Matches(msg, m.keys.List.EditStatus):
 		if task := m.getSelectedTask(); task != nil {
 			options := []ui.ModalOption{
 				{Label: "open", Value: "open", Shortcut: "o"},
@@ -633,7 +654,7 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			m.mode = ViewEditStatus
 		}
 
-	case key.Matches(msg, m.keys.EditPriority):
+	case key.Matches(msg, m.keys.List.EditPriority):
 		if task := m.getSelectedTask(); task != nil {
 			options := []ui.ModalOption{
 				{Label: "P0 - Critical", Value: "0", Shortcut: "0"},
@@ -646,7 +667,7 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			m.mode = ViewEditPriority
 		}
 
-	case key.Matches(msg, m.keys.EditType):
+	case key.Matches(msg, m.keys.List.EditType):
 		if task := m.getSelectedTask(); task != nil {
 			options := []ui.ModalOption{
 				{Label: "task", Value: "task", Shortcut: "t"},
@@ -659,7 +680,7 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			m.mode = ViewEditType
 		}
 
-	case key.Matches(msg, m.keys.EditDescription):
+	case key.Matches(msg, m.keys.List.EditDescription):
 		if task := m.getSelectedTask(); task != nil {
 			m.editField = "description"
 			m.modal = ui.NewModalTextarea("Edit Description", task.ID, task.Description, m.width, m.height)
@@ -667,7 +688,7 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.modal.Textarea.Focus()
 		}
 
-	case key.Matches(msg, m.keys.EditNotes):
+	case key.Matches(msg, m.keys.List.EditNotes):
 		if task := m.getSelectedTask(); task != nil {
 			m.editField = "notes"
 			m.modal = ui.NewModalTextarea("Edit Notes", task.ID, task.Notes, m.width, m.height)
@@ -675,7 +696,28 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.modal.Textarea.Focus()
 		}
 
-	case key.Matches(msg, m.keys.AddComment):
+	case key.Matches(msg, m.keys.List.EditLabels):
+		if task := m.getSelectedTask(); task != nil {
+			return m.openLabelEditor(task)
+		}
+
+	case key.Matches(msg, m.keys.List.EditAssignee):
+		if task := m.getSelectedTask(); task != nil {
+			return m.openAssigneePicker(task)
+		}
+
+	case key.Matches(msg, m.keys.List.EditDueDate):
+		if task := m.getSelectedTask(); task != nil {
+			return m.openDateEditor(task, "due")
+		}
+
+	case key.Matches(msg, m.keys.List.EditDeferDate):
+		if targets := m.targetTasks(); len(targets) > 0 {
+			m.selected = m.getSelectedTask()
+			m.openSnooze(targets)
+		}
+
+	case key.Matches(msg, m.keys.List.AddComment):
 		if task := m.getSelectedTask(); task != nil {
 			m.commentInput.SetValue("")
 			m.commentInput.Focus()
@@ -683,78 +725,75 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.commentInput.Focus()
 		}
 
-	case key.Matches(msg, m.keys.AddBlocker):
+	case key.Matches(msg, m.keys.List.AddBlocker):
 		if task := m.getSelectedTask(); task != nil {
-			// Build list of potential blockers (all other open tasks)
-			var options []ui.ModalOption
-			for _, t := range m.tasks {
-				if t.ID != task.ID && t.Status != "closed" {
-					// Check if already blocking
-					alreadyBlocking := false
-					for _, b := range task.BlockedBy {
-						if b == t.ID {
-							alreadyBlocking = true
-							break
-						}
-					}
-					if !alreadyBlocking {
-						label := fmt.Sprintf("%s - %s", t.ID, t.Title)
-						if len(label) > 50 {
-							label = label[:47] + "..."
-						}
-						options = append(options, ui.ModalOption{
-							Label: label,
-							Value: t.ID,
-						})
-					}
-				}
-			}
-			if len(options) == 0 {
-				m.statusMsg = "No available tasks to add as blocker"
-				return tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
-					return clearStatusMsg{}
-				})
+			m.openDependencyEditor(task)
+		}
+
+	case key.Matches(msg, m.keys.List.RemoveBlocker):
+		if task := m.getSelectedTask(); task != nil {
+			return m.openRemoveDependency(task)
+		}
+
+	case key.Matches(msg, m.keys.List.MoveParent):
+		targets := m.targetTasks()
+		if len(targets) == 0 {
+			break
+		}
+		// Candidate parents: open issues that are not one of the moved
+		// issues or below them (which would create a cycle)
+		options := []ui.ModalOption{{Label: "(root) Detach from parent", Value: detachParentValue}}
+		for i := range m.tasks {
+			candidate := &m.tasks[i]
+			if candidate.Status == "closed" || !m.canMoveUnder(targets, candidate.ID) {
+				continue
 			}
-			m.modal = ui.NewModalSelect("Add Blocker", task.ID, options, "")
-			m.mode = ViewAddBlocker
+			options = append(options, ui.ModalOption{
+				Label: pickerLabel(candidate),
+				Value: candidate.ID,
+			})
 		}
+		subtitle := targets[0].ID
+		if len(targets) > 1 {
+			subtitle = fmt.Sprintf("%d issues", len(targets))
+		}
+		m.modal = ui.NewModalPicker("Move Under", subtitle, options)
+		m.mode = ViewMoveParent
+		return m.modal.Input.Focus()
 
-	case key.Matches(msg, m.keys.RemoveBlocker):
+	case key.Matches(msg, m.keys.List.ToggleMark):
 		if task := m.getSelectedTask(); task != nil {
-			if len(task.BlockedBy) == 0 {
-				m.statusMsg = "No blockers to remove"
-				return tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
-					return clearStatusMsg{}
-				})
+			if m.marked[task.ID] {
+				delete(m.marked, task.ID)
+			} else {
+				m.marked[task.ID] = true
 			}
-			// Build list of current blockers
-			var options []ui.ModalOption
-			for _, blockerID := range task.BlockedBy {
-				label := blockerID
-				// Try to get title from tasks map
-				if blockerTask, ok := m.tasksMap[blockerID]; ok {
-					label = fmt.Sprintf("%s - %s", blockerID, blockerTask.Title)
-					if len(label) > 50 {
-						label = label[:47] + "..."
-					}
-				}
-				options = append(options, ui.ModalOption{
-					Label: label,
-					Value: blockerID,
-				})
+			currentID := task.ID
+			m.distributeTasks()
+			m.selectTaskByID(currentID)
+			// Advance so several issues can be marked in a row
+			m.scrollFocusedPanel(1)
+		}
+
+	case key.Matches(msg, m.keys.List.ClearMarks):
+		if len(m.marked) > 0 {
+			m.marked = make(map[string]bool)
+			currentID := ""
+			if task := m.getSelectedTask(); task != nil {
+				currentID = task.ID
 			}
-			m.modal = ui.NewModalSelect("Remove Blocker", task.ID, options, "")
-			m.mode = ViewRemoveBlocker
+			m.distributeTasks()
+			m.selectTaskByID(currentID)
 		}
 
-	case key.Matches(msg, m.keys.Filter):
+	case key.Matches(msg, m.keys.List.Filter):
 		// Enter inline search mode in status bar
 		m.searchMode = true
 		m.searchInput.SetValue(m.filterQuery)
 		m.searchInput.Focus()
 		return m.searchInput.Focus() // Return blink command
 
-	case key.Matches(msg, m.keys.CopyID):
+	case key.Matches(msg, m.keys.List.CopyID):
 		if task := m.getSelectedTask(); task != nil {
 			taskID := task.ID
 			return func() tea.Msg {
@@ -763,19 +802,35 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			}
 		}
 
-	case key.Matches(msg, m.keys.Sort):
+	case key.Matches(msg, m.keys.List.Sort):
 		// Cycle through sort modes
 		m.sortMode = (m.sortMode + 1) % sortModeCount
 		m.distributeTasks()
 
-	case key.Matches(msg, m.keys.Board):
+	case key.Matches(msg, m.keys.List.Graph):
+		if task := m.getSelectedTask(); task != nil {
+			m.openGraph(task)
+		}
+
+	case key.Matches(msg, m.keys.List.Report):
+		if task := m.getSelectedTask(); task != nil {
+			m.openReport(task)
+		}
+
+	case key.Matches(msg, m.keys.List.Timeline):
+		m.openTimeline()
+
+	case key.Matches(msg, m.keys.List.Calendar):
+		m.openCalendar()
+
+	case key.Matches(msg, m.keys.List.Board):
 		// Switch to board view
 		m.boardColumn = 0
 		m.boardRow = 0
 		m.boardColumnOffset = 0
 		m.mode = ViewBoard
 
-	case key.Matches(msg, m.keys.Open):
+	case key.Matches(msg, m.keys.List.Open):
 		// Toggle open filter (show open + in_progress only)
 		if m.filterMode == FilterOpen {
 			m.filterMode = FilterAll
@@ -784,7 +839,7 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 		}
 		m.distributeTasks()
 
-	case key.Matches(msg, m.keys.Closed):
+	case key.Matches(msg, m.keys.List.Closed):
 		// Toggle closed filter (show closed only)
 		if m.filterMode == FilterClosed {
 			m.filterMode = FilterAll
@@ -793,7 +848,7 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 		}
 		m.distributeTasks()
 
-	case key.Matches(msg, m.keys.Ready):
+	case key.Matches(msg, m.keys.List.Ready):
 		// Toggle ready filter (show tasks without blockers)
 		if m.filterMode == FilterReady {
 			m.filterMode = FilterAll
@@ -802,7 +857,25 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 		}
 		m.distributeTasks()
 
-	case key.Matches(msg, m.keys.All):
+	case key.Matches(msg, m.keys.List.Deferred):
+		// Toggle deferred filter (show only issues deferred to a later day)
+		if m.filterMode == FilterDeferred {
+			m.filterMode = FilterAll
+		} else {
+			m.filterMode = FilterDeferred
+		}
+		m.distributeTasks()
+
+	case key.Matches(msg, m.keys.List.Due):
+		// Toggle due filter (overdue and due-soon issues)
+		if m.filterMode == FilterDue {
+			m.filterMode = FilterAll
+		} else {
+			m.filterMode = FilterDue
+		}
+		m.distributeTasks()
+
+	case key.Matches(msg, m.keys.List.All):
 		// Clear filter mode
 		m.filterMode = FilterAll
 		m.distributeTasks()
@@ -819,16 +892,28 @@ func (m *Model) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 
 func (m *Model) handleDetailKeys(msg tea.KeyMsg) tea.Cmd {
 	switch {
-	case key.Matches(msg, m.keys.Cancel), key.Matches(msg, m.keys.Select):
-		// Return to where we came from (board or list)
-		if m.previousMode == ViewBoard {
-			m.mode = ViewBoard
-		} else {
-			m.mode = ViewList
-		}
-		m.previousMode = ViewList // Reset
-	case key.Matches(msg, m.keys.Help):
+	case key.Matches(msg, m.keys.Detail.Tab):
+		m.cycleDetailLink(1)
+	case key.Matches(msg, m.keys.Detail.ShiftTab):
+		m.cycleDetailLink(-1)
+	case key.Matches(msg, m.keys.Detail.Select) && m.focusedDetailLink() != "":
+		return m.navigateToIssue(m.focusedDetailLink())
+	case key.Matches(msg, m.keys.Detail.HistoryBack):
+		return m.historyBack()
+	case key.Matches(msg, m.keys.Detail.HistoryForward):
+		return m.historyForward()
+	case key.Matches(msg, m.keys.Detail.Cancel), key.Matches(msg, m.keys.Detail.Select):
+		m.closeDetail()
+	case key.Matches(msg, m.keys.Detail.Help):
 		m.mode = ViewHelp
+	case key.Matches(msg, m.keys.Detail.Graph):
+		if m.selected != nil {
+			m.openGraph(m.selected)
+		}
+	case key.Matches(msg, m.keys.Detail.Report):
+		if m.selected != nil {
+			m.openReport(m.selected)
+		}
 	default:
 		// Check custom commands
 		if cmd := m.matchCustomCommand(msg, "detail"); cmd != nil {
@@ -840,22 +925,19 @@ func (m *Model) handleDetailKeys(msg tea.KeyMsg) tea.Cmd {
 
 func (m *Model) handleFormKeys(msg tea.KeyMsg) tea.Cmd {
 	switch {
-	case key.Matches(msg, m.keys.Cancel):
+	case key.Matches(msg, m.keys.Form.Cancel):
 		m.mode = ViewList
 		return nil
 
-	case key.Matches(msg, m.keys.Submit):
+	case key.Matches(msg, m.keys.Form.Submit):
+		// Submits from any field
 		return m.submitForm()
 
-	case msg.String() == "enter":
-		// Enter submits from any field
-		return m.submitForm()
-
-	case key.Matches(msg, m.keys.Tab):
+	case key.Matches(msg, m.keys.Form.Tab):
 		m.formFocus = (m.formFocus + 1) % 4
 		m.updateFormFocus()
 
-	case key.Matches(msg, m.keys.ShiftTab):
+	case key.Matches(msg, m.keys.Form.ShiftTab):
 		m.formFocus = (m.formFocus - 1 + 4) % 4
 		m.updateFormFocus()
 	}
@@ -865,21 +947,21 @@ func (m *Model) handleFormKeys(msg tea.KeyMsg) tea.Cmd {
 
 func (m *Model) handleHelpKeys(msg tea.KeyMsg) tea.Cmd {
 	switch {
-	case key.Matches(msg, m.keys.Cancel), key.Matches(msg, m.keys.Help):
+	case key.Matches(msg, m.keys.List.Cancel), key.Matches(msg, m.keys.List.Help):
 		// Reset scroll position when closing help
 		m.helpViewport.GotoTop()
 		m.mode = ViewList
-	case key.Matches(msg, m.keys.Up):
+	case key.Matches(msg, m.keys.List.Up):
 		m.helpViewport.LineUp(1)
-	case key.Matches(msg, m.keys.Down):
+	case key.Matches(msg, m.keys.List.Down):
 		m.helpViewport.LineDown(1)
-	case key.Matches(msg, m.keys.PageUp):
+	case key.Matches(msg, m.keys.List.PageUp):
 		m.helpViewport.HalfViewUp()
-	case key.Matches(msg, m.keys.PageDown):
+	case key.Matches(msg, m.keys.List.PageDown):
 		m.helpViewport.HalfViewDown()
-	case key.Matches(msg, m.keys.Top):
+	case key.Matches(msg, m.keys.List.Top):
 		m.helpViewport.GotoTop()
-	case key.Matches(msg, m.keys.Bottom):
+	case key.Matches(msg, m.keys.List.Bottom):
 		m.helpViewport.GotoBottom()
 	}
 	return nil
@@ -888,10 +970,14 @@ func (m *Model) handleHelpKeys(msg tea.KeyMsg) tea.Cmd {
 func (m *Model) handleConfirmKeys(msg tea.KeyMsg) tea.Cmd {
 	switch msg.String() {
 	case "y", "Y":
-		if m.confirmAction != nil {
-			return m.confirmAction()
-		}
+		// Leave the prompt before running the action so a second "y" can't
+		// run it again while it is in flight
+		action := m.confirmAction
+		m.confirmAction = nil
 		m.mode = ViewList
+		if action != nil {
+			return action()
+		}
 	case "n", "N", "esc":
 		m.mode = ViewList
 	}
@@ -899,8 +985,8 @@ func (m *Model) handleConfirmKeys(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleTitleBarKeys(msg tea.KeyMsg) tea.Cmd {
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, m.keys.Form.Submit):
 		if m.selected != nil {
 			newTitle := strings.TrimSpace(m.modal.InputValue())
 			if newTitle != "" {
@@ -915,17 +1001,15 @@ func (m *Model) handleTitleBarKeys(msg tea.KeyMsg) tea.Cmd {
 			}
 		}
 		m.mode = ViewList
-	case "esc":
+	case key.Matches(msg, m.keys.Form.Cancel):
 		m.mode = ViewList
 	}
 	return nil
 }
 
 func (m *Model) handleSelectBarKeys(msg tea.KeyMsg) tea.Cmd {
-	key := msg.String()
-
 	// Check for shortcut keys first
-	if m.modal.SelectByShortcut(key) {
+	if m.modal.SelectByShortcut(msg.String()) {
 		// Shortcut matched, apply immediately
 		if m.selected != nil {
 			value := m.modal.SelectedValue()
@@ -935,12 +1019,12 @@ func (m *Model) handleSelectBarKeys(msg tea.KeyMsg) tea.Cmd {
 		}
 	}
 
-	switch key {
-	case "k", "up":
+	switch {
+	case key.Matches(msg, m.keys.Modal.Up):
 		m.modal.MoveUp()
-	case "j", "down":
+	case key.Matches(msg, m.keys.Modal.Down):
 		m.modal.MoveDown()
-	case "enter":
+	case key.Matches(msg, m.keys.Modal.Select):
 		if m.selected != nil {
 			value := m.modal.SelectedValue()
 			taskID := m.selected.ID
@@ -948,7 +1032,7 @@ func (m *Model) handleSelectBarKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.applyModalSelection(taskID, value)
 		}
 		m.mode = ViewList
-	case "esc":
+	case key.Matches(msg, m.keys.Modal.Cancel):
 		m.mode = ViewList
 	}
 	return nil
@@ -1008,13 +1092,13 @@ func (m *Model) handleSearchKeys(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleFilterKeys(msg tea.KeyMsg) tea.Cmd {
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, m.keys.Form.Submit):
 		// Apply filter and return to list
 		m.filterQuery = strings.TrimSpace(m.modal.InputValue())
 		m.distributeTasks()
 		m.mode = ViewList
-	case "esc":
+	case key.Matches(msg, m.keys.Form.Cancel):
 		// Cancel and return to list (don't change filter)
 		m.mode = ViewList
 	}
@@ -1022,8 +1106,8 @@ func (m *Model) handleFilterKeys(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleAddCommentKeys(msg tea.KeyMsg) tea.Cmd {
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, m.keys.Form.Submit):
 		// Submit comment
 		comment := strings.TrimSpace(m.commentInput.Value())
 		if comment != "" && m.selected != nil {
@@ -1036,63 +1120,139 @@ func (m *Model) handleAddCommentKeys(msg tea.KeyMsg) tea.Cmd {
 			}
 		}
 		m.mode = ViewList
-	case "esc":
+	case key.Matches(msg, m.keys.Form.Cancel):
 		m.commentInput.Blur()
 		m.mode = ViewList
 	}
 	return nil
 }
 
-func (m *Model) handleAddBlockerKeys(msg tea.KeyMsg) tea.Cmd {
-	key := msg.String()
-
-	switch key {
-	case "k", "up":
+func (m *Model) handleRemoveBlockerKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Modal.Up):
 		m.modal.MoveUp()
-	case "j", "down":
+	case key.Matches(msg, m.keys.Modal.Down):
 		m.modal.MoveDown()
-	case "enter":
+	case key.Matches(msg, m.keys.Modal.Select):
 		if m.selected != nil {
-			blockerID := m.modal.SelectedValue()
-			taskID := m.selected.ID
+			issueID, dependsOnID := m.selected.ID, m.modal.SelectedValue()
+			if id, ok := strings.CutPrefix(dependsOnID, reverseDepPrefix); ok {
+				// The other issue depends on this one
+				issueID, dependsOnID = id, issueID
+			}
 			m.mode = ViewList
 			return func() tea.Msg {
-				err := m.client.AddBlocker(taskID, blockerID)
-				return blockerAddedMsg{err: err}
+				err := m.client.RemoveDependency(issueID, dependsOnID)
+				return blockerRemovedMsg{err: err}
 			}
 		}
 		m.mode = ViewList
-	case "esc":
+	case key.Matches(msg, m.keys.Modal.Cancel):
 		m.mode = ViewList
 	}
 	return nil
 }
 
-func (m *Model) handleRemoveBlockerKeys(msg tea.KeyMsg) tea.Cmd {
-	key := msg.String()
+// detachParentValue is the picker value for moving issues to the root
+const detachParentValue = "__root__"
 
-	switch key {
-	case "k", "up":
+func (m *Model) handleMoveParentKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.pickerUp(msg):
 		m.modal.MoveUp()
-	case "j", "down":
+	case m.pickerDown(msg):
 		m.modal.MoveDown()
-	case "enter":
-		if m.selected != nil {
-			blockerID := m.modal.SelectedValue()
-			taskID := m.selected.ID
-			m.mode = ViewList
-			return func() tea.Msg {
-				err := m.client.RemoveBlocker(taskID, blockerID)
-				return blockerRemovedMsg{err: err}
-			}
-		}
+	case inputKey(msg, m.keys.Modal.Select):
+		value := m.modal.SelectedValue()
 		m.mode = ViewList
-	case "esc":
+		if value == "" {
+			return nil
+		}
+		if value == detachParentValue {
+			value = ""
+		}
+		return m.moveUnderParent(m.targetTasks(), value)
+	case key.Matches(msg, m.keys.Modal.Cancel):
 		m.mode = ViewList
 	}
 	return nil
 }
 
+// canMoveUnder reports whether parentID is a valid new parent for all of
+// the given tasks, i.e. it is not one of them and not one of their descendants
+func (m *Model) canMoveUnder(tasks []*models.Task, parentID string) bool {
+	for _, t := range tasks {
+		if t.ID == parentID || m.isDescendantOf(parentID, t.ID) {
+			return false
+		}
+	}
+	return true
+}
+
+// moveUnderParent re-parents the given tasks by replacing their parent-child
+// dependency. An empty newParentID detaches them to the root.
+func (m *Model) moveUnderParent(tasks []*models.Task, newParentID string) tea.Cmd {
+	type move struct {
+		id        string
+		oldParent string // explicit parent-child dependency to remove
+	}
+	var moves []move
+	for _, t := range tasks {
+		mv := move{id: t.ID}
+		for _, dep := range t.Dependencies {
+			if dep.IsParentChild() {
+				mv.oldParent = dep.DependsOnID
+				break
+			}
+		}
+		if mv.oldParent == newParentID && newParentID != "" {
+			continue // already there
+		}
+		if newParentID == "" && mv.oldParent == "" && models.ParentID(t.ID) != "" {
+			m.err = fmt.Errorf("%s: parent is implied by its hierarchical ID and cannot be detached", t.ID)
+			return nil
+		}
+		moves = append(moves, mv)
+	}
+	if len(moves) == 0 {
+		return nil
+	}
+
+	if newParentID != "" {
+		// Expand the new parent so the moved issues stay visible
+		delete(m.collapsedNodes, newParentID)
+	}
+	m.pendingSelectID = moves[0].id
+
+	return func() tea.Msg {
+		for i, mv := range moves {
+			if mv.oldParent != "" {
+				if err := m.client.RemoveDependency(mv.id, mv.oldParent); err != nil {
+					return parentChangedMsg{count: i, err: err}
+				}
+			}
+			if newParentID != "" {
+				if err := m.client.AddDependency(mv.id, newParentID, models.DepParentChild); err != nil {
+					// Put the issue back under its old parent rather than
+					// leaving it detached
+					if mv.oldParent != "" {
+						if restoreErr := m.client.AddDependency(mv.id, mv.oldParent, models.DepParentChild); restoreErr != nil {
+							err = fmt.Errorf("%w; %s is now detached from %s: %v", err, mv.id, mv.oldParent, restoreErr)
+						}
+					}
+					return parentChangedMsg{count: i, err: err}
+				}
+			}
+		}
+		return parentChangedMsg{count: len(moves)}
+	}
+}
+
+// pickerLabel formats a task as a single picker option label
+func pickerLabel(t *models.Task) string {
+	return fmt.Sprintf("%s - %s", t.ID, t.Title)
+}
+
 func (m *Model) handleBoardKeys(msg tea.KeyMsg) tea.Cmd {
 	const totalColumns = 5
 
@@ -1108,7 +1268,7 @@ func (m *Model) handleBoardKeys(msg tea.KeyMsg) tea.Cmd {
 	selectionChanged := false
 
 	switch {
-	case key.Matches(msg, m.keys.PrevView): // h/left - move to previous column
+	case key.Matches(msg, m.keys.Board.PrevView): // h/left - move to previous column
 		if m.boardColumn > 0 {
 			m.boardColumn--
 			newCount := columnCount(m.boardColumn)
@@ -1122,7 +1282,7 @@ func (m *Model) handleBoardKeys(msg tea.KeyMsg) tea.Cmd {
 			selectionChanged = true
 		}
 
-	case key.Matches(msg, m.keys.NextView): // l/right - move to next column
+	case key.Matches(msg, m.keys.Board.NextView): // l/right - move to next column
 		if m.boardColumn < totalColumns-1 {
 			m.boardColumn++
 			newCount := columnCount(m.boardColumn)
@@ -1136,33 +1296,33 @@ func (m *Model) handleBoardKeys(msg tea.KeyMsg) tea.Cmd {
 			selectionChanged = true
 		}
 
-	case key.Matches(msg, m.keys.Up): // k/up - move up in column
+	case key.Matches(msg, m.keys.Board.Up): // k/up - move up in column
 		if m.boardRow > 0 {
 			m.boardRow--
 			selectionChanged = true
 		}
 
-	case key.Matches(msg, m.keys.Down): // j/down - move down in column
+	case key.Matches(msg, m.keys.Board.Down): // j/down - move down in column
 		count := columnCount(m.boardColumn)
 		if m.boardRow < count-1 {
 			m.boardRow++
 			selectionChanged = true
 		}
 
-	case key.Matches(msg, m.keys.Top): // g - go to top
+	case key.Matches(msg, m.keys.Board.Top): // g - go to top
 		if m.boardRow != 0 {
 			m.boardRow = 0
 			selectionChanged = true
 		}
 
-	case key.Matches(msg, m.keys.Bottom): // G - go to bottom
+	case key.Matches(msg, m.keys.Board.Bottom): // G - go to bottom
 		count := columnCount(m.boardColumn)
 		if count > 0 && m.boardRow != count-1 {
 			m.boardRow = count - 1
 			selectionChanged = true
 		}
 
-	case key.Matches(msg, m.keys.Select): // enter - view task details
+	case key.Matches(msg, m.keys.Board.Select): // enter - view task details
 		task := m.getBoardSelectedTask()
 		if task != nil {
 			m.selected = task
@@ -1173,13 +1333,13 @@ func (m *Model) handleBoardKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.loadComments(task.ID)
 		}
 
-	case key.Matches(msg, m.keys.Board): // b - toggle back to list view
+	case key.Matches(msg, m.keys.Board.Board): // b - toggle back to list view
 		m.mode = ViewList
 
-	case key.Matches(msg, m.keys.Help):
+	case key.Matches(msg, m.keys.Board.Help):
 		m.mode = ViewHelp
 
-	case key.Matches(msg, m.keys.Cancel): // esc - back to list
+	case key.Matches(msg, m.keys.Board.Cancel): // esc - back to list
 		m.mode = ViewList
 	}
 
@@ -1218,9 +1378,9 @@ func (m *Model) ensureBoardColumnVisible() {
 }
 
 func (m *Model) handleTextEditKeys(msg tea.KeyMsg) tea.Cmd {
-	switch msg.String() {
-	case "ctrl+s":
-		// Save the edited text
+	switch {
+	case msg.Type != tea.KeyEnter && key.Matches(msg, m.keys.Form.Submit):
+		// Save the edited text; enter is left to the textarea
 		if m.selected != nil {
 			value := strings.TrimSpace(m.modal.TextareaValue())
 			taskID := m.selected.ID
@@ -1239,7 +1399,7 @@ func (m *Model) handleTextEditKeys(msg tea.KeyMsg) tea.Cmd {
 			}
 		}
 		m.mode = ViewList
-	case "esc":
+	case key.Matches(msg, m.keys.Form.Cancel):
 		// Cancel editing
 		m.mode = ViewList
 	}
@@ -1259,25 +1419,64 @@ func (m *Model) matchCustomCommand(msg tea.KeyMsg, context string) tea.Cmd {
 
 // executeCustomCommand renders and executes a custom command
 func (m *Model) executeCustomCommand(cmd config.CustomCommand) tea.Cmd {
+	// Commands that opt in apply to the marked issues (or the selected one),
+	// so they can run with the cursor on an empty panel
 	task := m.getSelectedTask()
+	targets := []*models.Task{task}
+	if cmd.Selection == "each" || cmd.Selection == "all" {
+		targets = m.targetTasks()
+		if task == nil && len(targets) > 0 {
+			task = targets[0]
+		}
+	}
 	if task == nil {
 		return nil
 	}
+	vars := commandVars{Task: task, Tasks: targets, Prompt: make(map[string]any)}
 
-	// Render command template
-	rendered, err := m.renderCommandTemplate(cmd.Command, task)
-	if err != nil {
-		m.err = fmt.Errorf("template error: %w", err)
-		return nil
+	// Ask the command's prompts first; it runs after the last answer
+	if len(cmd.Prompts) > 0 {
+		return m.startCommandPrompts(cmd, vars)
+	}
+	return m.runCustomCommand(cmd, vars)
+}
+
+// runCustomCommand renders a custom command with its variables and runs it
+// in the command's mode: once per issue in .Tasks for the "each" selection,
+// once otherwise
+func (m *Model) runCustomCommand(cmd config.CustomCommand, vars commandVars) tea.Cmd {
+	perIssue := []commandVars{vars}
+	if cmd.Selection == "each" {
+		perIssue = perIssue[:0]
+		for _, t := range vars.Tasks {
+			v := vars
+			v.Task = t
+			perIssue = append(perIssue, v)
+		}
 	}
 
-	// Execute command non-blocking (for tmux commands)
-	c := exec.Command("sh", "-c", rendered)
-	if err := c.Start(); err != nil {
-		m.err = fmt.Errorf("failed to execute command: %w", err)
+	var runs []commandRun
+	for _, v := range perIssue {
+		rendered, err := m.renderCommandTemplate(cmd.Command, v)
+		if err != nil {
+			m.err = fmt.Errorf("template error: %w", err)
+			return nil
+		}
+		run := commandRun{taskID: v.ID, command: rendered}
+		if cmd.Selection == "all" && len(v.Tasks) > 1 {
+			// No single issue to default to; patches must name theirs
+			run.taskID = ""
+		}
+		runs = append(runs, run)
 	}
 
-	return nil
+	switch cmd.Mode {
+	case "interactive":
+		return runCommandsInteractively(cmd, runs)
+	case "capture", "patch":
+		m.statusMsg = "Running " + cmd.Description + "..."
+	}
+	return runCommandsInBackground(cmd, runs)
 }
 
 // shellEscape escapes a string for safe use in shell commands
@@ -1291,19 +1490,22 @@ func shellEscape(s string) string {
 	return s
 }
 
-// renderCommandTemplate renders the command template with task data
-func (m *Model) renderCommandTemplate(cmdTemplate string, task *models.Task) (string, error) {
-	funcMap := template.FuncMap{
-		"sh": shellEscape,
-	}
+// shellQuote quotes a string as a single shell word: wrapped in single
+// quotes, inside which nothing but a single quote needs escaping
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, `'`, `'\''`) + "'"
+}
 
-	tmpl, err := template.New("cmd").Funcs(funcMap).Parse(cmdTemplate)
+// renderCommandTemplate renders the command template with task data and
+// prompt answers
+func (m *Model) renderCommandTemplate(cmdTemplate string, vars commandVars) (string, error) {
+	tmpl, err := newCommandTemplate().Parse(cmdTemplate)
 	if err != nil {
 		return "", err
 	}
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, task); err != nil {
+	if err := tmpl.Execute(&buf, vars); err != nil {
 		return "", err
 	}
 
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/config"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// customCommandContexts maps a custom command context to the keybinding
+// contexts its key is checked in
+var customCommandContexts = map[string][]string{
+	"list":   {ui.ContextList},
+	"detail": {ui.ContextDetail},
+	"global": {ui.ContextList, ui.ContextDetail},
+}
+
+// buildKeyMaps returns the effective key maps for a config, with custom
+// commands added to the list help, along with any keybinding problems
+func buildKeyMaps(cfg *config.Config) (ui.KeyMaps, []config.Problem) {
+	keys, errs := ui.BuildKeyMaps(cfg.KeyOverrides())
+	keys.List.CustomCommands = buildCustomCommandBindings(cfg.CustomCommands)
+
+	var problems []config.Problem
+	for _, err := range errs {
+		setting := "keybindings." + err.Context
+		if err.Binding != "" {
+			setting += "." + err.Binding
+		}
+		problems = append(problems, config.Problem{Location: cfg.Locate(setting), Message: err.Error()})
+	}
+
+	// Built-in bindings are matched before custom commands, so a custom
+	// command sharing a key with one never runs
+	for _, cmd := range cfg.CustomCommands {
+		for _, context := range customCommandContexts[cmd.Context] {
+			if name := keys.BindingFor(context, cmd.Key); name != "" {
+				problems = append(problems, config.Problem{
+					Location: cfg.Locate("customCommands." + cmd.Key),
+					Message: fmt.Sprintf("custom command %q (%s) is shadowed by built-in %s in the %s context",
+						cmd.Key, cmd.Description, name, context),
+				})
+			}
+		}
+	}
+	return keys, problems
+}
+
+// activeKeys returns the key map of the context the current view belongs to
+func (m *Model) activeKeys() ui.KeyMap {
+	switch m.mode {
+	case ViewDetail:
+		return m.keys.Detail
+	case ViewBoard:
+		return m.keys.Board
+	case ViewForm, ViewEditTitle, ViewEditText, ViewAddComment, ViewFilter,
+		ViewEditLabels, ViewEditAssignee, ViewEditDate:
+		return m.keys.Form
+	case ViewConfirm, ViewEditStatus, ViewEditPriority, ViewEditType,
+		ViewAddBlocker, ViewRemoveBlocker, ViewMoveParent, ViewPickTemplate,
+		ViewPickDepType, ViewSnooze, ViewCommandPrompt:
+		return m.keys.Modal
+	}
+	return m.keys.List
+}
+
+// inputKey reports whether msg triggers binding in a view with a text
+// input. Printable keys always go to the input, so there a binding such as
+// the default j/k only acts through its other keys.
+func inputKey(msg tea.KeyMsg, binding key.Binding) bool {
+	return msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace && key.Matches(msg, binding)
+}
+
+// pickerUp and pickerDown report whether msg moves the highlight of a
+// filterable picker: the modal up/down bindings, or ctrl+p/ctrl+n
+func (m *Model) pickerUp(msg tea.KeyMsg) bool {
+	return msg.String() == "ctrl+p" || inputKey(msg, m.keys.Modal.Up)
+}
+
+func (m *Model) pickerDown(msg tea.KeyMsg) bool {
+	return msg.String() == "ctrl+n" || inputKey(msg, m.keys.Modal.Down)
+}
@@ -20,13 +20,15 @@ type tasksLoadedMsg struct {
 
 // taskCreatedMsg is sent when a task is created
 type taskCreatedMsg struct {
-	task *models.Task
-	err  error
+	task     *models.Task
+	parentID string
+	err      error
 }
 
 // taskUpdatedMsg is sent when a task is updated
 type taskUpdatedMsg struct {
-	err error
+	status string // flashed in the status bar on success
+	err    error
 }
 
 // taskClosedMsg is sent when a task is closed
@@ -59,16 +61,42 @@ type commentAddedMsg struct {
 	err error
 }
 
-// blockerAddedMsg is sent when a blocker is added
+// blockerAddedMsg is sent when a dependency is added
 type blockerAddedMsg struct {
-	err error
+	depType string
+	err     error
 }
 
-// blockerRemovedMsg is sent when a blocker is removed
+// blockerRemovedMsg is sent when a dependency is removed
 type blockerRemovedMsg struct {
 	err error
 }
 
+// snoozedMsg is sent when the defer date of issues is changed from the
+// snooze menu; until is empty when they were woken
+type snoozedMsg struct {
+	ids   []string
+	until string
+	err   error
+}
+
+// parentChangedMsg is sent when issues are moved under a new parent
+type parentChangedMsg struct {
+	count int
+	err   error
+}
+
+// templateStepMsg is sent when a step of a template run finishes
+type templateStepMsg struct {
+	err error
+}
+
+// templateRollbackMsg is sent when a failed template run has been cleaned up
+type templateRollbackMsg struct {
+	deleted int
+	err     error
+}
+
 // tickMsg triggers periodic refresh
 type tickMsg time.Time
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -68,14 +69,37 @@ func (d panelDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 		treeIndicator = "  "
 	}
 
-	// Blocked indicator
-	blockedIndicator := ""
+	treePrefix := indent + treeIndicator
+
+	// Leading segments: tree prefix, [marked], [blocked], [cycle],
+	// [bottleneck], priority, issueID, [due], [child progress], [wake date].
+	// The title follows and is truncated to fit the remaining width.
+	segments := []rowSegment{
+		{treePrefix, lipgloss.NewStyle().Foreground(ui.ColorMuted)},
+	}
+	if t.marked {
+		segments = append(segments, rowSegment{"✓", lipgloss.NewStyle().Foreground(ui.ColorAccent).Bold(true)})
+	}
 	if t.task.IsBlocked() {
-		blockedIndicator = "⊘"
+		segments = append(segments, rowSegment{"⊘", lipgloss.NewStyle().Foreground(ui.ColorDanger)})
+	}
+	if t.inCycle {
+		segments = append(segments, rowSegment{"⟳", lipgloss.NewStyle().Foreground(ui.ColorDanger).Bold(true)})
+	}
+	if t.unblocks >= bottleneckBadgeMin {
+		segments = append(segments, rowSegment{fmt.Sprintf("»%d", t.unblocks), lipgloss.NewStyle().Foreground(ui.ColorWarning)})
+	}
+	segments = append(segments,
+		rowSegment{t.task.PriorityString(), ui.PriorityStyle(t.task.Priority)},
+		rowSegment{t.task.ID, lipgloss.NewStyle().Foreground(ui.ColorMuted)},
+	)
+	if badge := dueBadge(t.task, t.due); badge.text != "" {
+		segments = append(segments, badge)
+	}
+	segments = append(segments, rollupSegments(t.task, t.rollup)...)
+	if t.task.IsDeferred(time.Now()) {
+		segments = append(segments, rowSegment{"↷" + t.task.DeferUntil.Format("01-02"), lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true)})
 	}
-
-	priority := t.task.PriorityString()
-	issueID := t.task.ID
 	title := t.task.Title
 
 	width := m.Width()
@@ -84,14 +108,11 @@ func (d panelDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 	}
 
 	// Calculate available width for title
-	// Format: indent + treeIndicator + [blocked] + priority + issueID + title
-	treePrefix := indent + treeIndicator
-	var prefixWidth int
-	if blockedIndicator != "" {
-		prefixWidth = lipgloss.Width(fmt.Sprintf("%s %s %s %s ", treePrefix, blockedIndicator, priority, issueID))
-	} else {
-		prefixWidth = lipgloss.Width(fmt.Sprintf("%s %s %s ", treePrefix, priority, issueID))
+	plainParts := make([]string, len(segments))
+	for i, seg := range segments {
+		plainParts[i] = seg.text
 	}
+	prefixWidth := lipgloss.Width(strings.Join(plainParts, " ") + " ")
 	maxTitleWidth := width - prefixWidth
 	if maxTitleWidth < 5 {
 		maxTitleWidth = 5
@@ -107,45 +128,31 @@ func (d panelDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 
 	if isSelected && focused {
 		// Show highlight only when panel is focused
-		var line string
-		if blockedIndicator != "" {
-			line = fmt.Sprintf("%s %s %s %s %s", treePrefix, blockedIndicator, priority, issueID, title)
-		} else {
-			line = fmt.Sprintf("%s %s %s %s", treePrefix, priority, issueID, title)
-		}
-		style := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("15")).
-			Background(lipgloss.Color("#2a4a6d")).
-			Bold(true).
-			Width(width)
+		line := strings.Join(append(plainParts, title), " ")
+		style := ui.SelectedRowStyle.Width(width)
 		fmt.Fprint(w, style.Render(line))
 	} else {
-		priorityStyle := ui.PriorityStyle(t.task.Priority)
-		idStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted)
-		blockedStyle := lipgloss.NewStyle().Foreground(ui.ColorDanger)
-		treeStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted)
-
-		var line string
-		if blockedIndicator != "" {
-			line = fmt.Sprintf("%s %s %s %s %s",
-				treeStyle.Render(treePrefix),
-				blockedStyle.Render(blockedIndicator),
-				priorityStyle.Render(priority),
-				idStyle.Render(issueID),
-				title)
-		} else {
-			line = fmt.Sprintf("%s %s %s %s",
-				treeStyle.Render(treePrefix),
-				priorityStyle.Render(priority),
-				idStyle.Render(issueID),
-				title)
+		styledParts := make([]string, 0, len(segments)+1)
+		for _, seg := range segments {
+			styledParts = append(styledParts, seg.style.Render(seg.text))
+		}
+		if t.due == models.DueOverdue {
+			title = lipgloss.NewStyle().Foreground(ui.ColorDanger).Render(title)
 		}
+		line := strings.Join(append(styledParts, title), " ")
 		// Ensure line doesn't exceed width
 		style := lipgloss.NewStyle().Width(width).MaxWidth(width)
 		fmt.Fprint(w, style.Render(line))
 	}
 }
 
+// rowSegment is a piece of a panel row rendered with its own style when the
+// row is not highlighted
+type rowSegment struct {
+	text  string
+	style lipgloss.Style
+}
+
 // NewPanel creates a new panel with the given title
 func NewPanel(title string) PanelModel {
 	delegate := newPanelDelegate()
@@ -514,3 +521,23 @@ func (p PanelModel) viewCollapsed() string {
 
 	return topBorder + "\n" + middleRow + "\n" + bottomBorder
 }
+
+// dueBadge flags overdue ("!3d" late) and due-soon ("◷2d" left) rows
+func dueBadge(t models.Task, due models.DueStatus) rowSegment {
+	days := t.DaysUntilDue(time.Now())
+	switch due {
+	case models.DueOverdue:
+		return rowSegment{fmt.Sprintf("!%dd", -days), lipgloss.NewStyle().Foreground(ui.ColorDanger).Bold(true)}
+	case models.DueSoon:
+		text := fmt.Sprintf("◷%dd", days)
+		switch {
+		case days == 0:
+			text = "◷today"
+		case days < 0:
+			// Late, but still within the grace period
+			text = fmt.Sprintf("◷%dd late", -days)
+		}
+		return rowSegment{text, lipgloss.NewStyle().Foreground(ui.ColorWarning)}
+	}
+	return rowSegment{}
+}
@@ -0,0 +1,236 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/beads"
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// patchStatuses are the statuses a command patch may set
+var patchStatuses = []string{"open", "in_progress", "closed"}
+
+// commandPatch is a change to an issue, printed as JSON on stdout by a
+// custom command in patch mode
+type commandPatch struct {
+	ID       string   `json:"id"` // defaults to the issue the command ran for, if one
+	Status   string   `json:"status"`
+	Priority *int     `json:"priority"`
+	Labels   []string `json:"labels"`  // labels to add; "-name" removes one
+	Notes    string   `json:"notes"`   // appended to the issue's notes
+	Comment  string   `json:"comment"` // added as a new comment
+}
+
+// runOutput is the stdout of one run of a patch mode command
+type runOutput struct {
+	taskID string
+	stdout string
+}
+
+// patchesAppliedMsg is sent when the patches from a command were applied
+type patchesAppliedMsg struct {
+	applied int // issues changed before any error
+	err     error
+}
+
+// parsePatches parses a patch mode command's stdout: a single patch object,
+// an array of them, or nothing at all. Patches without an id apply to
+// taskID, and are an error if it is empty.
+func parsePatches(taskID, stdout string) ([]commandPatch, error) {
+	stdout = strings.TrimSpace(stdout)
+	if stdout == "" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(stdout))
+	dec.DisallowUnknownFields()
+	var patches []commandPatch
+	if strings.HasPrefix(stdout, "[") {
+		if err := dec.Decode(&patches); err != nil {
+			return nil, err
+		}
+	} else {
+		var p commandPatch
+		if err := dec.Decode(&p); err != nil {
+			return nil, err
+		}
+		patches = append(patches, p)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected output after the patch")
+	}
+
+	for i := range patches {
+		p := &patches[i]
+		if p.ID == "" {
+			if taskID == "" {
+				return nil, fmt.Errorf("patch %d has no id, which is required for several issues", i+1)
+			}
+			p.ID = taskID
+		}
+		if p.Status != "" && !slices.Contains(patchStatuses, p.Status) {
+			return nil, fmt.Errorf("%s: unknown status %q (use %s)", p.ID, p.Status, strings.Join(patchStatuses, ", "))
+		}
+		if p.Priority != nil && (*p.Priority < 0 || *p.Priority > 4) {
+			return nil, fmt.Errorf("%s: priority must be 0-4, got %d", p.ID, *p.Priority)
+		}
+	}
+	return patches, nil
+}
+
+// splitLabels separates the labels a patch adds from those it removes
+func (p commandPatch) splitLabels() (add, remove []string) {
+	for _, label := range p.Labels {
+		if name, ok := strings.CutPrefix(label, "-"); ok {
+			remove = append(remove, name)
+		} else {
+			add = append(add, strings.TrimPrefix(label, "+"))
+		}
+	}
+	return add, remove
+}
+
+// describePatch lists the changes a patch makes to a task, one per line,
+// leaving out values the task already has
+func describePatch(task *models.Task, p commandPatch) []string {
+	var lines []string
+	if p.Status != "" && p.Status != task.Status {
+		lines = append(lines, fmt.Sprintf("status: %s → %s", task.Status, p.Status))
+	}
+	if p.Priority != nil && *p.Priority != task.Priority {
+		lines = append(lines, fmt.Sprintf("priority: P%d → P%d", task.Priority, *p.Priority))
+	}
+	add, remove := p.splitLabels()
+	var labels []string
+	for _, l := range add {
+		if !slices.Contains(task.Labels, l) {
+			labels = append(labels, "+"+l)
+		}
+	}
+	for _, l := range remove {
+		if slices.Contains(task.Labels, l) {
+			labels = append(labels, "-"+l)
+		}
+	}
+	if len(labels) > 0 {
+		lines = append(lines, "labels: "+strings.Join(labels, " "))
+	}
+	if p.Notes != "" {
+		lines = append(lines, "notes: + "+ui.Truncate(firstLine(p.Notes), 60))
+	}
+	if p.Comment != "" {
+		lines = append(lines, "comment: "+ui.Truncate(firstLine(p.Comment), 60))
+	}
+	return lines
+}
+
+// firstLine returns the first line of s, marking that more follows
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if line, _, more := strings.Cut(s, "\n"); more {
+		return line + " …"
+	}
+	return s
+}
+
+// previewPatches parses the output of a patch mode command and asks for
+// confirmation before applying the changes
+func (m *Model) previewPatches(msg customCommandDoneMsg) tea.Cmd {
+	var patches []commandPatch
+	for _, out := range msg.stdouts {
+		parsed, err := parsePatches(out.taskID, out.stdout)
+		if err != nil {
+			if out.taskID == "" {
+				m.err = fmt.Errorf("%s: invalid patch: %w", msg.cmd.Description, err)
+			} else {
+				m.err = fmt.Errorf("%s: invalid patch for %s: %w", msg.cmd.Description, out.taskID, err)
+			}
+			return nil
+		}
+		patches = append(patches, parsed...)
+	}
+
+	var b strings.Builder
+	var changes []commandPatch
+	for _, p := range patches {
+		task, ok := m.tasksMap[p.ID]
+		if !ok {
+			m.err = fmt.Errorf("%s: patch for unknown issue %s", msg.cmd.Description, p.ID)
+			return nil
+		}
+		lines := describePatch(task, p)
+		if len(lines) == 0 {
+			continue
+		}
+		changes = append(changes, p)
+		fmt.Fprintf(&b, "\n%s %s\n", task.ID, ui.Truncate(task.Title, 50))
+		for _, line := range lines {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	if len(changes) == 0 {
+		m.statusMsg = msg.cmd.Description + ": no changes"
+		return tea.Tick(statusFlashDuration, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+	}
+
+	m.confirmMsg = fmt.Sprintf("Apply changes from %s?\n%s", msg.cmd.Description, b.String())
+	m.confirmAction = func() tea.Cmd {
+		return m.applyPatches(changes)
+	}
+	m.mode = ViewConfirm
+	return nil
+}
+
+// applyPatches applies patches through bd, one issue at a time. Notes are
+// appended to the notes loaded when the patch was previewed, and to each
+// other when several patches target the same issue.
+func (m *Model) applyPatches(patches []commandPatch) tea.Cmd {
+	notes := make(map[string]string)
+	for _, p := range patches {
+		if task, ok := m.tasksMap[p.ID]; ok {
+			notes[p.ID] = task.Notes
+		}
+	}
+
+	return func() tea.Msg {
+		applied := 0
+		for _, p := range patches {
+			opts := beads.UpdateOptions{Status: p.Status, Priority: p.Priority}
+			opts.AddLabels, opts.RemoveLabels = p.splitLabels()
+			if p.Notes != "" {
+				opts.Notes = strings.TrimSpace(p.Notes)
+				if existing := strings.TrimRight(notes[p.ID], "\n"); existing != "" {
+					opts.Notes = existing + "\n\n" + opts.Notes
+				}
+			}
+			// A patch with only a comment has nothing to update
+			if opts.Status != "" || opts.Priority != nil || opts.Notes != "" ||
+				len(opts.AddLabels) > 0 || len(opts.RemoveLabels) > 0 {
+				if err := m.client.Update(p.ID, opts); err != nil {
+					return patchesAppliedMsg{applied: applied, err: fmt.Errorf("%s: %w", p.ID, err)}
+				}
+				if opts.Notes != "" {
+					notes[p.ID] = opts.Notes
+				}
+			}
+			if p.Comment != "" {
+				if err := m.client.AddComment(p.ID, p.Comment); err != nil {
+					return patchesAppliedMsg{applied: applied, err: fmt.Errorf("%s: comment: %w", p.ID, err)}
+				}
+			}
+			applied++
+		}
+		return patchesAppliedMsg{applied: applied}
+	}
+}
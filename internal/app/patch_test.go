@@ -0,0 +1,112 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/josebiro/bb/internal/models"
+)
+
+func TestParsePatches(t *testing.T) {
+	p := func(n int) *int { return &n }
+
+	tests := []struct {
+		name   string
+		stdout string
+		want   []commandPatch
+	}{
+		{"empty", "", nil},
+		{"whitespace", "  \n\t", nil},
+		{"object defaults id", `{"status": "closed"}`, []commandPatch{{ID: "bb-1", Status: "closed"}}},
+		{"object with id", `{"id": "bb-2", "priority": 0}`, []commandPatch{{ID: "bb-2", Priority: p(0)}}},
+		{"array", `[{"labels": ["a", "-b"]}, {"id": "bb-3", "comment": "hi"}]` + "\n",
+			[]commandPatch{{ID: "bb-1", Labels: []string{"a", "-b"}}, {ID: "bb-3", Comment: "hi"}}},
+		{"empty array", `[]`, []commandPatch{}},
+		{"notes", `{"notes": "line one\nline two"}`, []commandPatch{{ID: "bb-1", Notes: "line one\nline two"}}},
+	}
+	for _, tt := range tests {
+		got, err := parsePatches("bb-1", tt.stdout)
+		if err != nil {
+			t.Errorf("%s: parsePatches returned error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parsePatches = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParsePatchesInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+	}{
+		{"not json", "done"},
+		{"unknown field", `{"title": "renamed"}`},
+		{"unknown field in array", `[{"status": "open"}, {"assignee": "me"}]`},
+		{"trailing object", `{"status": "open"} {"status": "closed"}`},
+		{"trailing text", "{\"status\": \"open\"}\nok"},
+		{"unknown status", `{"status": "done"}`},
+		{"priority too high", `{"priority": 5}`},
+		{"priority negative", `{"priority": -1}`},
+		{"wrong type", `{"labels": "a"}`},
+	}
+	for _, tt := range tests {
+		if _, err := parsePatches("bb-1", tt.stdout); err == nil {
+			t.Errorf("%s: parsePatches(%q) expected error", tt.name, tt.stdout)
+		}
+	}
+}
+
+func TestParsePatchesWithoutTask(t *testing.T) {
+	// A command run once for several issues has no issue to default to
+	if _, err := parsePatches("", `[{"id": "bb-2", "status": "open"}, {"status": "closed"}]`); err == nil {
+		t.Error("expected error for a patch without an id")
+	}
+	got, err := parsePatches("", `{"id": "bb-2", "status": "open"}`)
+	if err != nil || len(got) != 1 || got[0].ID != "bb-2" {
+		t.Errorf("parsePatches = %+v, %v, want one patch for bb-2", got, err)
+	}
+}
+
+func TestSplitLabels(t *testing.T) {
+	tests := []struct {
+		labels []string
+		add    []string
+		remove []string
+	}{
+		{nil, nil, nil},
+		{[]string{"a", "+b"}, []string{"a", "b"}, nil},
+		{[]string{"-a", "b", "-c"}, []string{"b"}, []string{"a", "c"}},
+	}
+	for _, tt := range tests {
+		add, remove := commandPatch{Labels: tt.labels}.splitLabels()
+		if !reflect.DeepEqual(add, tt.add) || !reflect.DeepEqual(remove, tt.remove) {
+			t.Errorf("splitLabels(%q) = %q, %q, want %q, %q", tt.labels, add, remove, tt.add, tt.remove)
+		}
+	}
+}
+
+func TestDescribePatch(t *testing.T) {
+	p := func(n int) *int { return &n }
+	task := &models.Task{ID: "bb-1", Status: "open", Priority: 2, Labels: []string{"ui", "bug"}}
+
+	tests := []struct {
+		name  string
+		patch commandPatch
+		want  []string
+	}{
+		{"no changes", commandPatch{}, nil},
+		{"same values", commandPatch{Status: "open", Priority: p(2), Labels: []string{"ui", "-docs"}}, nil},
+		{"status", commandPatch{Status: "closed"}, []string{"status: open → closed"}},
+		{"priority", commandPatch{Priority: p(0)}, []string{"priority: P2 → P0"}},
+		{"labels", commandPatch{Labels: []string{"+api", "ui", "-bug"}}, []string{"labels: +api -bug"}},
+		{"notes", commandPatch{Notes: "first\nsecond"}, []string{"notes: + first …"}},
+		{"comment", commandPatch{Comment: "  looks good  "}, []string{"comment: looks good"}},
+	}
+	for _, tt := range tests {
+		if got := describePatch(task, tt.patch); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: describePatch = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/config"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// commandPromptRun is a custom command waiting for answers to its prompts
+type commandPromptRun struct {
+	cmd  config.CustomCommand
+	vars commandVars
+	step int // index of the prompt being asked
+}
+
+// startCommandPrompts asks the first prompt of a custom command
+func (m *Model) startCommandPrompts(cmd config.CustomCommand, vars commandVars) tea.Cmd {
+	m.promptRun = &commandPromptRun{cmd: cmd, vars: vars}
+	return m.askPrompt()
+}
+
+// askPrompt opens the modal for the current prompt
+func (m *Model) askPrompt() tea.Cmd {
+	run := m.promptRun
+	p := run.cmd.Prompts[run.step]
+	subtitle := run.cmd.Description
+	if len(run.cmd.Prompts) > 1 {
+		subtitle = fmt.Sprintf("%s (%d/%d)", subtitle, run.step+1, len(run.cmd.Prompts))
+	}
+
+	m.mode = ViewCommandPrompt
+	switch p.Type {
+	case "choice":
+		options := make([]ui.ModalOption, len(p.Options))
+		for i, opt := range p.Options {
+			options[i] = ui.ModalOption{Label: opt, Value: opt}
+			if i < 9 {
+				options[i].Shortcut = strconv.Itoa(i + 1)
+			}
+		}
+		m.modal = ui.NewModalSelect(p.Title, subtitle, options, p.Default)
+	case "confirm":
+		options := []ui.ModalOption{
+			{Label: "Yes", Value: "true", Shortcut: "y"},
+			{Label: "No", Value: "false", Shortcut: "n"},
+		}
+		current := "true"
+		if p.Default == "false" {
+			current = "false"
+		}
+		m.modal = ui.NewModalSelect(p.Title, subtitle, options, current)
+	case "issue":
+		var options []ui.ModalOption
+		for i := range m.tasks {
+			t := &m.tasks[i]
+			options = append(options, ui.ModalOption{Label: pickerLabel(t), Value: t.ID, Group: m.epicGroup(t)})
+		}
+		// Issues under an epic first, grouped by epic; the rest at the end
+		sort.SliceStable(options, func(i, j int) bool {
+			a, b := options[i], options[j]
+			if (a.Group == "") != (b.Group == "") {
+				return b.Group == ""
+			}
+			if a.Group != b.Group {
+				return a.Group < b.Group
+			}
+			return a.Value < b.Value
+		})
+		for i := range options {
+			if options[i].Group == "" {
+				options[i].Group = "Not in an epic"
+			}
+		}
+		m.modal = ui.NewModalPicker(p.Title, subtitle, options)
+		m.modal.Help = "type to filter  ↑/↓: nav  enter: choose  esc: cancel"
+		return m.modal.Input.Focus()
+	default:
+		m.modal = ui.NewModalInput(p.Title, subtitle, p.Default)
+		return m.modal.Input.Focus()
+	}
+	return nil
+}
+
+// answerPrompt records the answer to the current prompt, then asks the
+// next one or runs the command
+func (m *Model) answerPrompt(value string) tea.Cmd {
+	run := m.promptRun
+	p := run.cmd.Prompts[run.step]
+	if p.Type == "confirm" {
+		run.vars.Prompt[p.Name] = value == "true"
+	} else {
+		run.vars.Prompt[p.Name] = value
+	}
+
+	run.step++
+	if run.step < len(run.cmd.Prompts) {
+		return m.askPrompt()
+	}
+	m.promptRun = nil
+	m.mode = ViewList
+	return m.runCustomCommand(run.cmd, run.vars)
+}
+
+// cancelPrompts abandons a custom command waiting for answers
+func (m *Model) cancelPrompts() {
+	m.promptRun = nil
+	m.mode = ViewList
+}
+
+// handleCommandPromptKeys answers prompts. Typed input is handled here
+// rather than passed on by Update, since answering opens the next prompt in
+// the same view.
+func (m *Model) handleCommandPromptKeys(msg tea.KeyMsg) tea.Cmd {
+	if m.promptRun == nil {
+		m.mode = ViewList
+		return nil
+	}
+
+	switch m.modal.Type {
+	case ui.ModalSelect:
+		if m.modal.SelectByShortcut(msg.String()) {
+			return m.answerPrompt(m.modal.SelectedValue())
+		}
+		switch {
+		case key.Matches(msg, m.keys.Modal.Up):
+			m.modal.MoveUp()
+		case key.Matches(msg, m.keys.Modal.Down):
+			m.modal.MoveDown()
+		case key.Matches(msg, m.keys.Modal.Select):
+			return m.answerPrompt(m.modal.SelectedValue())
+		}
+	case ui.ModalPicker:
+		switch {
+		case m.pickerUp(msg):
+			m.modal.MoveUp()
+		case m.pickerDown(msg):
+			m.modal.MoveDown()
+		case inputKey(msg, m.keys.Modal.Select):
+			if opt, ok := m.modal.SelectedOption(); ok {
+				return m.answerPrompt(opt.Value)
+			}
+		default:
+			var cmd tea.Cmd
+			m.modal.Input, cmd = m.modal.Input.Update(msg)
+			m.modal.ApplyFilter()
+			return cmd
+		}
+	default:
+		if inputKey(msg, m.keys.Modal.Select) {
+			return m.answerPrompt(m.modal.InputValue())
+		}
+		var cmd tea.Cmd
+		m.modal.Input, cmd = m.modal.Input.Update(msg)
+		return cmd
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/config"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// configReloadedMsg is sent when a config file changed and was loaded again
+type configReloadedMsg struct {
+	stamp string
+	cfg   *config.Config
+	err   error
+}
+
+// configStamp fingerprints the config files by size and modification
+// time, so creating, editing or removing any of them changes it
+func configStamp() string {
+	var b strings.Builder
+	for _, path := range config.WatchPaths() {
+		b.WriteString(path)
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, " %d %d", info.Size(), info.ModTime().UnixNano())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// checkConfig reloads the config if its files changed since stamp. It runs
+// on every poll tick and returns no message when nothing changed.
+func checkConfig(stamp string) tea.Cmd {
+	return func() tea.Msg {
+		current := configStamp()
+		if current == stamp {
+			return nil
+		}
+		cfg, err := config.Load()
+		return configReloadedMsg{stamp: current, cfg: cfg, err: err}
+	}
+}
+
+// applyConfig rebuilds everything derived from the config: theme, key maps
+// (including the custom command bindings), custom commands, templates and
+// due thresholds
+func (m *Model) applyConfig(cfg *config.Config) {
+	theme, _ := resolveTheme(cfg)
+	ui.ApplyTheme(theme)
+
+	m.keys, _ = buildKeyMaps(cfg)
+	m.customCommands = cfg.CustomCommands
+	m.templates = cfg.Templates
+	m.dueSoonDays, m.dueGraceDays = cfg.Due.Thresholds()
+	m.configProblems = ConfigProblems(cfg)
+}
+
+// handleConfigReloaded applies a reloaded config. If it could not be read,
+// or a file could not be parsed, the last good config stays in effect.
+func (m *Model) handleConfigReloaded(msg configReloadedMsg) {
+	m.configStamp = msg.stamp
+	switch {
+	case msg.err != nil:
+		m.statusMsg = "Config not reloaded: " + msg.err.Error()
+		return
+	case msg.cfg.Fatal() != nil:
+		m.statusMsg = "Config not reloaded: " + msg.cfg.Fatal().Error()
+		m.configProblems = ConfigProblems(msg.cfg)
+		m.updateSizes()
+		return
+	}
+
+	m.applyConfig(msg.cfg)
+	m.distributeTasks()
+	m.updateSizes()
+	if n := len(m.configProblems); n > 0 {
+		m.statusMsg = fmt.Sprintf("Config reloaded with %d problem(s)", n)
+	} else {
+		m.statusMsg = "Config reloaded"
+	}
+}
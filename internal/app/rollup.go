@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// rollupBarWidth is the width of the progress bar in panel rows and cards
+const rollupBarWidth = 5
+
+// rollupProgress renders "███░░ 3/5" for an issue with children
+func rollupProgress(r models.Rollup, width int) string {
+	return fmt.Sprintf("%s %d/%d", ui.ProgressBar(r.Done, r.Total, width), r.Done, r.Total)
+}
+
+// rollupSegments returns the panel/card badges for an issue's descendants:
+// progress, the most urgent open child priority when it outranks the issue
+// itself, and the earliest open child due date
+func rollupSegments(t models.Task, r models.Rollup) []rowSegment {
+	if r.Total == 0 {
+		return nil
+	}
+	progressColor := ui.ColorAccent
+	if r.Done == r.Total {
+		progressColor = ui.ColorPrimary
+	}
+	segments := []rowSegment{
+		{rollupProgress(r, rollupBarWidth), lipgloss.NewStyle().Foreground(progressColor)},
+	}
+	if r.Priority >= 0 && r.Priority < t.Priority {
+		segments = append(segments, rowSegment{"↑P" + fmt.Sprint(r.Priority), ui.PriorityStyle(r.Priority)})
+	}
+	if r.Due != nil {
+		segments = append(segments, rowSegment{"◷" + r.Due.Format("01-02"), lipgloss.NewStyle().Foreground(ui.ColorMuted)})
+	}
+	return segments
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/josebiro/bb/internal/config"
+)
+
+// panelNames are the names panels are saved under in the session state
+var panelNames = map[PanelFocus]string{
+	FocusInProgress: "in-progress",
+	FocusOpen:       "open",
+	FocusClosed:     "closed",
+}
+
+// Session returns the UI state to restore on the next start
+func (m Model) Session() config.Session {
+	s := config.Session{
+		FocusedPanel: panelNames[m.focusedPanel],
+		FilterQuery:  m.filterQuery,
+		FilterMode:   m.filterMode.String(),
+		SortMode:     m.sortMode.String(),
+		BoardColumn:  m.boardColumn,
+	}
+	for id, collapsed := range m.collapsedNodes {
+		if collapsed {
+			s.CollapsedNodes = append(s.CollapsedNodes, id)
+		}
+	}
+	sort.Strings(s.CollapsedNodes)
+
+	selected := m.selected
+	if m.mode == ViewBoard {
+		selected = m.getBoardSelectedTask()
+	} else if task := m.getSelectedTask(); task != nil {
+		selected = task
+	}
+	if selected != nil {
+		s.SelectedID = selected.ID
+	}
+	return s
+}
+
+// RestoreSession applies saved UI state. Unknown names are ignored; the
+// selected issue is selected once the tasks have loaded.
+func (m *Model) RestoreSession(s config.Session) {
+	for focus, name := range panelNames {
+		if name == s.FocusedPanel && focus != m.focusedPanel {
+			m.focusPanelByType(focus)
+		}
+	}
+	for f := FilterAll; f < filterModeCount; f++ {
+		if f.String() == s.FilterMode {
+			m.filterMode = f
+		}
+	}
+	for sm := SortDefault; sm < sortModeCount; sm++ {
+		if sm.String() == s.SortMode {
+			m.sortMode = sm
+		}
+	}
+	m.filterQuery = s.FilterQuery
+	m.searchInput.SetValue(s.FilterQuery)
+	for _, id := range s.CollapsedNodes {
+		m.collapsedNodes[id] = true
+	}
+	if s.BoardColumn >= 0 && s.BoardColumn < boardColumnCount {
+		m.boardColumn = s.BoardColumn
+	}
+	m.pendingSelectID = s.SelectedID
+}
@@ -0,0 +1,311 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/josebiro/bb/internal/beads"
+	"github.com/josebiro/bb/internal/config"
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// templateVars are the variables available to issue template text
+type templateVars struct {
+	ParentID string // parent issue ID (empty for root issues)
+	Title    string // title of the parent issue, for children
+	Type     string // issue type being created
+	Date     string // today's date (YYYY-MM-DD)
+}
+
+// renderIssueTemplate renders a template text field with the given variables
+func renderIssueTemplate(text string, vars templateVars) (string, error) {
+	tmpl, err := template.New("issue").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// today returns the current date formatted for template variables
+func today() string {
+	return time.Now().Format("2006-01-02")
+}
+
+// openCreateForm starts creating a new issue (under parentID, if set). When
+// templates are configured the user picks one first.
+func (m *Model) openCreateForm(parentID string) {
+	m.resetForm()
+	m.formParentID = parentID
+	if parentID != "" {
+		m.formType = "task"
+	}
+	m.editing = false
+
+	if len(m.templates) > 0 {
+		options := []ui.ModalOption{{Label: "Blank", Value: "", Shortcut: "0"}}
+		for i, t := range m.templates {
+			opt := ui.ModalOption{
+				Label: fmt.Sprintf("%s (%s)", t.Name, t.Type),
+				Value: fmt.Sprintf("%d", i),
+			}
+			if i < 9 {
+				opt.Shortcut = fmt.Sprintf("%d", i+1)
+			}
+			options = append(options, opt)
+		}
+		subtitle := ""
+		if parentID != "" {
+			subtitle = "under " + parentID
+		}
+		m.modal = ui.NewModalSelect("New Issue", subtitle, options, "")
+		m.mode = ViewPickTemplate
+		return
+	}
+
+	m.mode = ViewForm
+	m.formTitle.Focus()
+}
+
+func (m *Model) handlePickTemplateKeys(msg tea.KeyMsg) tea.Cmd {
+	if m.modal.SelectByShortcut(msg.String()) {
+		return m.startFormFromTemplate(m.modal.SelectedValue())
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Modal.Up):
+		m.modal.MoveUp()
+	case key.Matches(msg, m.keys.Modal.Down):
+		m.modal.MoveDown()
+	case key.Matches(msg, m.keys.Modal.Select):
+		return m.startFormFromTemplate(m.modal.SelectedValue())
+	case key.Matches(msg, m.keys.Modal.Cancel):
+		m.mode = ViewList
+	}
+	return nil
+}
+
+// startFormFromTemplate opens the create form, pre-filled from the template
+// at the given index ("" for a blank form)
+func (m *Model) startFormFromTemplate(value string) tea.Cmd {
+	var idx int
+	if _, err := fmt.Sscanf(value, "%d", &idx); err == nil && idx >= 0 && idx < len(m.templates) {
+		m.applyTemplate(&m.templates[idx])
+	}
+	m.mode = ViewForm
+	m.formFocus = 0
+	m.updateFormFocus()
+	m.formTitle.CursorEnd()
+	return nil
+}
+
+// applyTemplate pre-fills the create form from a template
+func (m *Model) applyTemplate(t *config.IssueTemplate) {
+	vars := templateVars{
+		ParentID: m.formParentID,
+		Type:     t.Type,
+		Date:     today(),
+	}
+	if parent, ok := m.tasksMap[m.formParentID]; ok {
+		vars.Title = parent.Title
+	}
+
+	title, err := renderIssueTemplate(t.TitlePrefix, vars)
+	if err != nil {
+		m.err = fmt.Errorf("template %q title: %w", t.Name, err)
+		title = t.TitlePrefix
+	}
+	desc, err := renderIssueTemplate(t.Description, vars)
+	if err != nil {
+		m.err = fmt.Errorf("template %q description: %w", t.Name, err)
+		desc = t.Description
+	}
+
+	m.formTitle.SetValue(title)
+	m.formDesc.SetValue(desc)
+	m.formType = t.Type
+	if t.Priority != nil {
+		m.formPriority = *t.Priority
+	}
+	m.formLabels = t.Labels
+	m.formTemplate = t
+}
+
+// templateRun creates an issue plus the tree of children (and the
+// dependencies between them) defined by a template, one bd call per step.
+// If a step fails, the issues created so far are deleted again.
+type templateRun struct {
+	name    string
+	steps   []templateStep
+	done    int                     // number of completed steps
+	created []string                // issue IDs in creation order (for rollback)
+	issues  map[string]*models.Task // template child ID -> created issue ("" = root)
+	failure error                   // set when a step failed (rollback pending)
+}
+
+// templateStep is a single bd operation within a template run
+type templateStep struct {
+	desc string
+	run  func(c *beads.Client, r *templateRun) error
+}
+
+// newTemplateRun plans the steps for creating root and the template's
+// children. Children are created depth-first, then dependencies are added.
+func newTemplateRun(t *config.IssueTemplate, root beads.CreateOptions) (*templateRun, error) {
+	r := &templateRun{
+		name:   t.Name,
+		issues: make(map[string]*models.Task),
+	}
+
+	r.steps = append(r.steps, templateStep{
+		desc: fmt.Sprintf("create %q", root.Title),
+		run: func(c *beads.Client, r *templateRun) error {
+			task, err := c.Create(root)
+			if err != nil {
+				return err
+			}
+			r.created = append(r.created, task.ID)
+			r.issues[""] = task
+			return nil
+		},
+	})
+
+	type dependency struct {
+		key, dependsOn string
+	}
+	var deps []dependency
+	keys := map[string]bool{"": true}
+	n := 0
+
+	var walk func(children []config.TemplateChild, parentKey string) error
+	walk = func(children []config.TemplateChild, parentKey string) error {
+		for i := range children {
+			child := children[i]
+			n++
+			key := child.ID
+			if key == "" {
+				key = fmt.Sprintf("#%d", n)
+			} else if keys[key] {
+				return fmt.Errorf("template %q: duplicate child id %q", t.Name, key)
+			}
+			keys[key] = true
+			if child.Title == "" {
+				return fmt.Errorf("template %q: child %s has no title", t.Name, key)
+			}
+
+			r.steps = append(r.steps, templateStep{
+				desc: fmt.Sprintf("create %q", child.Title),
+				run: func(c *beads.Client, r *templateRun) error {
+					parent := r.issues[parentKey]
+					vars := templateVars{
+						ParentID: parent.ID,
+						Title:    parent.Title,
+						Type:     child.Type,
+						Date:     today(),
+					}
+					title, err := renderIssueTemplate(child.Title, vars)
+					if err != nil {
+						return fmt.Errorf("title: %w", err)
+					}
+					desc, err := renderIssueTemplate(child.Description, vars)
+					if err != nil {
+						return fmt.Errorf("description: %w", err)
+					}
+					priority := 2
+					if child.Priority != nil {
+						priority = *child.Priority
+					}
+					task, err := c.Create(beads.CreateOptions{
+						Title:       title,
+						Description: desc,
+						Type:        child.Type,
+						Priority:    priority,
+						Labels:      child.Labels,
+						Parent:      parent.ID,
+					})
+					if err != nil {
+						return err
+					}
+					r.created = append(r.created, task.ID)
+					r.issues[key] = task
+					return nil
+				},
+			})
+
+			for _, dep := range child.DependsOn {
+				deps = append(deps, dependency{key: key, dependsOn: dep})
+			}
+			if err := walk(child.Children, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(t.Children, ""); err != nil {
+		return nil, err
+	}
+
+	for _, dep := range deps {
+		if !keys[dep.dependsOn] || dep.dependsOn == "" {
+			return nil, fmt.Errorf("template %q: %s depends on unknown child id %q", t.Name, dep.key, dep.dependsOn)
+		}
+		r.steps = append(r.steps, templateStep{
+			desc: fmt.Sprintf("link %s → %s", dep.dependsOn, dep.key),
+			run: func(c *beads.Client, r *templateRun) error {
+				return c.AddBlocker(r.issues[dep.key].ID, r.issues[dep.dependsOn].ID)
+			},
+		})
+	}
+
+	return r, nil
+}
+
+// rootID returns the ID of the issue created from the template itself
+func (r *templateRun) rootID() string {
+	if root, ok := r.issues[""]; ok {
+		return root.ID
+	}
+	return ""
+}
+
+// runTemplateStep executes the next step of the run. Steps only record
+// created issues on the run; progress (done) is advanced in Update.
+func (m Model) runTemplateStep(r *templateRun) tea.Cmd {
+	step := r.steps[r.done]
+	return func() tea.Msg {
+		if err := step.run(m.client, r); err != nil {
+			return templateStepMsg{err: fmt.Errorf("%s: %w", step.desc, err)}
+		}
+		return templateStepMsg{}
+	}
+}
+
+// rollbackTemplateRun deletes the issues created so far, newest first
+func (m Model) rollbackTemplateRun(r *templateRun) tea.Cmd {
+	created := append([]string(nil), r.created...)
+	return func() tea.Msg {
+		var firstErr error
+		deleted := 0
+		for i := len(created) - 1; i >= 0; i-- {
+			if err := m.client.Delete(created[i]); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			deleted++
+		}
+		return templateRollbackMsg{deleted: deleted, err: firstErr}
+	}
+}
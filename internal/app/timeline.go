@@ -0,0 +1,614 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/josebiro/bb/internal/models"
+	"github.com/josebiro/bb/internal/ui"
+)
+
+// timelineZoom is how much time one timeline column covers
+type timelineZoom int
+
+const (
+	zoomDay timelineZoom = iota
+	zoomWeek
+	zoomMonth
+)
+
+var timelineZoomNames = [...]string{"day", "week", "month"}
+
+const (
+	// timelineLabelWidth is the width of the issue column left of the bars
+	timelineLabelWidth = 34
+	// timelineMinCells is the narrowest chart drawn
+	timelineMinCells = 10
+	// timelineNoEpic heads issues that do not belong to an epic
+	timelineNoEpic = "Not in an epic"
+)
+
+// dateOnly returns midnight UTC of the calendar day t falls on in its own
+// location, so dates from bd and from the local clock compare by day
+func dateOnly(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+}
+
+// todayDate returns the current local date as a dateOnly value
+func todayDate() time.Time {
+	return dateOnly(time.Now())
+}
+
+// timelineSpan is the stretch of time an issue's bar covers, in days
+type timelineSpan struct {
+	start    time.Time // created
+	active   time.Time // deferred until; the bar before this is drawn as waiting
+	end      time.Time // closed, due, or today
+	overdue  bool      // still open past its due date (end is today)
+	due      time.Time // due date, zero if none
+	hasStart bool
+}
+
+// spanOf works out the bar for an issue: it starts when the issue was
+// created and ends when it was closed, at its due date, or today for open
+// issues without one. Overdue issues run on to today.
+func spanOf(t *models.Task, now time.Time) timelineSpan {
+	var s timelineSpan
+	switch {
+	case !t.CreatedAt.IsZero():
+		s.start = dateOnly(t.CreatedAt)
+	case t.DeferUntil != nil:
+		s.start = dateOnly(*t.DeferUntil)
+	case t.DueDate != nil:
+		s.start = dateOnly(*t.DueDate)
+	default:
+		return s
+	}
+	s.hasStart = true
+	s.active = s.start
+	if t.DeferUntil != nil && dateOnly(*t.DeferUntil).After(s.start) {
+		s.active = dateOnly(*t.DeferUntil)
+	}
+	if t.DueDate != nil {
+		s.due = dateOnly(*t.DueDate)
+	}
+
+	switch {
+	case t.Status == "closed" && t.ClosedAt != nil:
+		s.end = dateOnly(*t.ClosedAt)
+	case t.Status == "closed":
+		s.end = dateOnly(t.UpdatedAt)
+	case t.DueDate != nil && s.due.Before(now):
+		s.end = now
+		s.overdue = true
+	case t.DueDate != nil:
+		s.end = s.due
+	default:
+		s.end = now
+	}
+	if s.active.After(s.end) {
+		s.end = s.active
+	}
+	if s.end.Before(s.start) {
+		s.end = s.start
+	}
+	return s
+}
+
+// timelineRow is one line of the timeline: an epic heading or an issue
+type timelineRow struct {
+	heading string
+	task    *models.Task
+}
+
+// timelineRows groups the issues passing the current filter by epic, each
+// group ordered by start date. Issues outside any epic come last.
+func (m *Model) timelineRows() []timelineRow {
+	groups := make(map[string][]*models.Task)
+	for i := range m.tasks {
+		t := &m.tasks[i]
+		if !m.matchesFilter(*t) {
+			continue
+		}
+		group := m.epicGroup(t)
+		groups[group] = append(groups[group], t)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "") != (names[j] == "") {
+			return names[j] == ""
+		}
+		return names[i] < names[j]
+	})
+
+	now := todayDate()
+	var rows []timelineRow
+	for _, name := range names {
+		tasks := groups[name]
+		sort.SliceStable(tasks, func(i, j int) bool {
+			a, b := spanOf(tasks[i], now), spanOf(tasks[j], now)
+			if !a.start.Equal(b.start) {
+				return a.start.Before(b.start)
+			}
+			return tasks[i].ID < tasks[j].ID
+		})
+		heading := name
+		if heading == "" {
+			heading = timelineNoEpic
+		}
+		rows = append(rows, timelineRow{heading: heading})
+		for _, t := range tasks {
+			rows = append(rows, timelineRow{task: t})
+		}
+	}
+	return rows
+}
+
+// timelineIssues returns the issue rows only, in display order
+func timelineIssues(rows []timelineRow) []*models.Task {
+	var tasks []*models.Task
+	for _, r := range rows {
+		if r.task != nil {
+			tasks = append(tasks, r.task)
+		}
+	}
+	return tasks
+}
+
+// timelineCells is how many columns the chart has at the current width
+func (m Model) timelineCells() int {
+	cells := m.width - timelineLabelWidth - 1
+	if cells < timelineMinCells {
+		cells = timelineMinCells
+	}
+	return cells
+}
+
+// alignTimeline moves a date back to the start of its column at the given zoom
+func alignTimeline(d time.Time, zoom timelineZoom) time.Time {
+	d = dateOnly(d)
+	switch zoom {
+	case zoomWeek:
+		offset := (int(d.Weekday()) + 6) % 7 // weeks start on Monday
+		return d.AddDate(0, 0, -offset)
+	case zoomMonth:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return d
+}
+
+// timelineCell returns the column a date falls in (may be off screen)
+func (m Model) timelineCell(d time.Time) int {
+	d = dateOnly(d)
+	switch m.timelineZoom {
+	case zoomWeek:
+		return floorDiv(daysBetween(m.timelineStart, d), 7)
+	case zoomMonth:
+		return (d.Year()-m.timelineStart.Year())*12 + int(d.Month()) - int(m.timelineStart.Month())
+	}
+	return daysBetween(m.timelineStart, d)
+}
+
+// timelineDate returns the first date of a column
+func (m Model) timelineDate(cell int) time.Time {
+	switch m.timelineZoom {
+	case zoomWeek:
+		return m.timelineStart.AddDate(0, 0, 7*cell)
+	case zoomMonth:
+		return m.timelineStart.AddDate(0, cell, 0)
+	}
+	return m.timelineStart.AddDate(0, 0, cell)
+}
+
+// daysBetween counts whole days from a to b (both dateOnly values)
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours()) / 24
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// openTimeline shows the timeline with today a quarter of the way in and
+// the current issue selected
+func (m *Model) openTimeline() {
+	m.timelineStart = alignTimeline(todayDate(), m.timelineZoom)
+	m.timelineStart = m.timelineDate(-m.timelineCells() / 4)
+	m.timelineRow = 0
+	if task := m.getSelectedTask(); task != nil {
+		for i, t := range timelineIssues(m.timelineRows()) {
+			if t.ID == task.ID {
+				m.timelineRow = i
+			}
+		}
+	}
+	m.mode = ViewTimeline
+}
+
+// setTimelineZoom changes the zoom level, keeping the middle of the chart
+// in place
+func (m *Model) setTimelineZoom(zoom timelineZoom) {
+	cells := m.timelineCells()
+	center := m.timelineDate(cells / 2)
+	m.timelineZoom = zoom
+	m.timelineStart = alignTimeline(center, zoom)
+	m.timelineStart = m.timelineDate(-cells / 2)
+}
+
+func (m *Model) handleTimelineKeys(msg tea.KeyMsg) tea.Cmd {
+	issues := timelineIssues(m.timelineRows())
+	if m.timelineRow >= len(issues) {
+		m.timelineRow = len(issues) - 1
+	}
+	if m.timelineRow < 0 {
+		m.timelineRow = 0
+	}
+	pan := m.timelineCells() / 4
+	if pan < 1 {
+		pan = 1
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.List.Up):
+		if m.timelineRow > 0 {
+			m.timelineRow--
+		}
+	case key.Matches(msg, m.keys.List.Down):
+		if m.timelineRow < len(issues)-1 {
+			m.timelineRow++
+		}
+	case key.Matches(msg, m.keys.List.Top):
+		m.timelineRow = 0
+	case key.Matches(msg, m.keys.List.Bottom):
+		m.timelineRow = max(len(issues)-1, 0)
+	case key.Matches(msg, m.keys.List.PrevView): // h/left - earlier
+		m.timelineStart = m.timelineDate(-pan)
+	case key.Matches(msg, m.keys.List.NextView): // l/right - later
+		m.timelineStart = m.timelineDate(pan)
+	case msg.String() == "+" || msg.String() == "=":
+		if m.timelineZoom > zoomDay {
+			m.setTimelineZoom(m.timelineZoom - 1)
+		}
+	case msg.String() == "-":
+		if m.timelineZoom < zoomMonth {
+			m.setTimelineZoom(m.timelineZoom + 1)
+		}
+	case msg.String() == ".": // back to today
+		m.timelineStart = alignTimeline(todayDate(), m.timelineZoom)
+		m.timelineStart = m.timelineDate(-m.timelineCells() / 4)
+	case msg.String() == "f": // scroll to the selected issue's bar
+		if m.timelineRow >= 0 && m.timelineRow < len(issues) {
+			if s := spanOf(issues[m.timelineRow], todayDate()); s.hasStart {
+				m.timelineStart = alignTimeline(s.start, m.timelineZoom)
+				m.timelineStart = m.timelineDate(-1)
+			}
+		}
+	case key.Matches(msg, m.keys.List.Select):
+		if m.timelineRow >= 0 && m.timelineRow < len(issues) {
+			task := issues[m.timelineRow]
+			m.revealTask(task.ID)
+			m.selected = task
+			m.comments = nil
+			m.updateDetailContent()
+			m.previousMode = ViewTimeline
+			m.mode = ViewDetail
+			return m.loadComments(task.ID)
+		}
+	case key.Matches(msg, m.keys.List.Timeline), key.Matches(msg, m.keys.List.Cancel):
+		m.mode = ViewList
+	case key.Matches(msg, m.keys.List.Help):
+		m.mode = ViewHelp
+	}
+	return nil
+}
+
+// timelineHeader renders the two scale lines above the chart: a coarse
+// line (month or year) and a fine line (day, month). Labels go at period
+// boundaries; the first column is labelled too if that fits before the
+// first boundary label.
+func (m Model) timelineHeader(cells int) (string, string) {
+	coarse := []rune(strings.Repeat(" ", cells))
+	fine := []rune(strings.Repeat(" ", cells))
+	place := func(line []rune, cell int, label string) {
+		end := cell + len([]rune(label))
+		if end > cells {
+			return
+		}
+		// Keep a blank column between labels
+		for c := max(cell-1, 0); c <= end && c < cells; c++ {
+			if line[c] != ' ' {
+				return
+			}
+		}
+		copy(line[cell:], []rune(label))
+	}
+
+	var coarseFirst, fineFirst string
+	for c := 0; c < cells; c++ {
+		d := m.timelineDate(c)
+		var coarseLabel, fineLabel string
+		switch m.timelineZoom {
+		case zoomDay:
+			coarseFirst = m.timelineStart.Format("Jan 2006")
+			if d.Day() == 1 {
+				coarseLabel = d.Format("Jan 2006")
+			}
+			if d.Weekday() == time.Monday {
+				fineLabel = d.Format("02")
+			}
+		case zoomWeek:
+			coarseFirst, fineFirst = m.timelineStart.Format("2006"), m.timelineStart.Format("Jan")
+			prev := d.AddDate(0, 0, -7)
+			if d.Year() != prev.Year() {
+				coarseLabel = d.Format("2006")
+			}
+			if d.Month() != prev.Month() {
+				fineLabel = d.Format("Jan")
+			}
+		case zoomMonth:
+			coarseFirst = m.timelineStart.Format("2006")
+			if d.Month() == time.January {
+				coarseLabel = d.Format("2006")
+			}
+			// One letter per month, no gaps
+			fine[c] = []rune(d.Format("Jan"))[0]
+		}
+		if coarseLabel != "" {
+			place(coarse, c, coarseLabel)
+		}
+		if fineLabel != "" {
+			place(fine, c, fineLabel)
+		}
+	}
+	if coarseFirst != "" {
+		place(coarse, 0, coarseFirst)
+	}
+	if fineFirst != "" {
+		place(fine, 0, fineFirst)
+	}
+	return string(coarse), string(fine)
+}
+
+// timelineCell kinds, in drawing order
+const (
+	cellEmpty = iota
+	cellToday
+	cellArrow
+	cellWaiting
+	cellBar
+	cellOverdue
+)
+
+// timelineBar renders the chart part of an issue row. blockerEnd is the
+// column the latest visible blocker finishes in, or nil; when there is room
+// an arrow is drawn from there to the start of this bar.
+func (m Model) timelineBar(t *models.Task, s timelineSpan, cells, todayCell int, blockerEnd *int) string {
+	kinds := make([]int, cells)
+	if todayCell >= 0 && todayCell < cells {
+		kinds[todayCell] = cellToday
+	}
+
+	startCell, endCell := 0, -1
+	if s.hasStart {
+		startCell, endCell = m.timelineCell(s.start), m.timelineCell(s.end)
+		activeCell := m.timelineCell(s.active)
+		dueCell := endCell
+		if s.overdue {
+			dueCell = m.timelineCell(s.due)
+		}
+		for c := max(startCell, 0); c <= endCell && c < cells; c++ {
+			switch {
+			case c < activeCell:
+				kinds[c] = cellWaiting
+			case c > dueCell:
+				kinds[c] = cellOverdue
+			default:
+				kinds[c] = cellBar
+			}
+		}
+	}
+
+	// Dependency arrow from the blocker's end to this bar's start
+	arrowFrom, arrowTo := -1, -1
+	if blockerEnd != nil && s.hasStart && startCell-*blockerEnd >= 3 {
+		arrowFrom, arrowTo = max(*blockerEnd+1, 0), min(startCell-1, cells-1)
+		for c := arrowFrom; c <= arrowTo; c++ {
+			if kinds[c] == cellEmpty || kinds[c] == cellToday {
+				kinds[c] = cellArrow
+			}
+		}
+	}
+
+	barColor := ui.StatusColors[t.Status]
+	if barColor == "" {
+		barColor = ui.ColorMuted
+	}
+	if s.overdue {
+		barColor = ui.ColorDanger
+	}
+	styles := map[int]lipgloss.Style{
+		cellToday:   lipgloss.NewStyle().Foreground(ui.ColorWarning),
+		cellArrow:   lipgloss.NewStyle().Foreground(ui.ColorAccent),
+		cellWaiting: lipgloss.NewStyle().Foreground(barColor),
+		cellBar:     lipgloss.NewStyle().Foreground(barColor),
+		cellOverdue: lipgloss.NewStyle().Foreground(ui.ColorDanger).Bold(true),
+	}
+
+	var b strings.Builder
+	for c := 0; c < cells; {
+		// Group runs of the same kind so each gets a single escape sequence
+		end := c
+		var run strings.Builder
+		for end < cells && kinds[end] == kinds[c] {
+			run.WriteString(m.timelineGlyph(kinds[end], end, startCell, endCell, arrowTo, cells))
+			end++
+		}
+		if style, ok := styles[kinds[c]]; ok {
+			b.WriteString(style.Render(run.String()))
+		} else {
+			b.WriteString(run.String())
+		}
+		c = end
+	}
+	return b.String()
+}
+
+// timelineGlyph picks the character for one column of a row
+func (m Model) timelineGlyph(kind, cell, startCell, endCell, arrowTo, cells int) string {
+	switch kind {
+	case cellToday:
+		return "┊"
+	case cellArrow:
+		if cell == arrowTo {
+			return "▶"
+		}
+		return "─"
+	case cellWaiting:
+		return "░"
+	case cellBar, cellOverdue:
+		// Clipped bars get an arrow where they leave the screen
+		if cell == 0 && startCell < 0 {
+			return "◀"
+		}
+		if cell == cells-1 && endCell >= cells {
+			return "▶"
+		}
+		if kind == cellOverdue {
+			return "▓"
+		}
+		return "█"
+	}
+	return " "
+}
+
+// timelineLabel renders the issue column of a row
+func timelineLabel(t *models.Task, selected bool) string {
+	text := padText(ui.Truncate(t.PriorityString()+" "+t.ID+" "+t.Title, timelineLabelWidth-1), timelineLabelWidth)
+	if selected {
+		return ui.SelectedRowStyle.Render(text)
+	}
+	priority := ui.PriorityStyle(t.Priority).Render(t.PriorityString())
+	return priority + text[len(t.PriorityString()):]
+}
+
+func (m Model) viewTimeline() string {
+	var b strings.Builder
+	now := todayDate()
+	cells := m.timelineCells()
+	rows := m.timelineRows()
+	issues := timelineIssues(rows)
+
+	last := m.timelineDate(cells).AddDate(0, 0, -1)
+	title := fmt.Sprintf("TIMELINE  by %s  %s – %s", timelineZoomNames[m.timelineZoom],
+		m.timelineStart.Format(models.DateLayout), last.Format(models.DateLayout))
+	b.WriteString(ui.TitleStyle.Render(title) + "\n")
+
+	coarse, fine := m.timelineHeader(cells)
+	labelPad := strings.Repeat(" ", timelineLabelWidth+1)
+	b.WriteString(labelPad + ui.HelpDescStyle.Render(coarse) + "\n")
+	b.WriteString(labelPad + ui.HelpDescStyle.Render(fine) + "\n")
+
+	// Columns each issue's bar ends in, for dependency arrows
+	ends := make(map[string]int)
+	spans := make(map[string]timelineSpan)
+	for _, t := range issues {
+		s := spanOf(t, now)
+		spans[t.ID] = s
+		if s.hasStart {
+			ends[t.ID] = m.timelineCell(s.end)
+		}
+	}
+
+	// Find the selected row's line and scroll it into view
+	bodyHeight := m.height - 6
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+	selectedLine, issueIdx := 0, 0
+	for i, r := range rows {
+		if r.task != nil {
+			if issueIdx == m.timelineRow {
+				selectedLine = i
+			}
+			issueIdx++
+		}
+	}
+	offset := 0
+	if selectedLine >= bodyHeight {
+		offset = selectedLine - bodyHeight/2
+	}
+	if offset > len(rows)-bodyHeight {
+		offset = max(len(rows)-bodyHeight, 0)
+	}
+
+	todayCell := m.timelineCell(now)
+	var lines []string
+	for i := offset; i < len(rows) && len(lines) < bodyHeight; i++ {
+		r := rows[i]
+		if r.task == nil {
+			lines = append(lines, ui.FormLabelStyle.Render(ui.Truncate(r.heading, m.width)))
+			continue
+		}
+		var blockerEnd *int
+		for _, id := range m.depGraph.Blockers(r.task.ID) {
+			if end, ok := ends[id]; ok && (blockerEnd == nil || end > *blockerEnd) {
+				blockerEnd = &end
+			}
+		}
+		label := timelineLabel(r.task, i == selectedLine)
+		lines = append(lines, label+" "+m.timelineBar(r.task, spans[r.task.ID], cells, todayCell, blockerEnd))
+	}
+	if len(issues) == 0 {
+		lines = append(lines, ui.HelpDescStyle.Render("  No issues match the current filter."))
+	}
+	for len(lines) < bodyHeight {
+		lines = append(lines, "")
+	}
+	b.WriteString(strings.Join(lines, "\n") + "\n")
+
+	// Dates of the selected issue
+	var info string
+	if m.timelineRow >= 0 && m.timelineRow < len(issues) {
+		t := issues[m.timelineRow]
+		s := spans[t.ID]
+		info = t.ID + " [" + t.Status + "]"
+		if s.hasStart {
+			info += "  created " + s.start.Format(models.DateLayout)
+		}
+		if s.active.After(s.start) {
+			info += "  deferred until " + s.active.Format(models.DateLayout)
+		}
+		switch {
+		case t.Status == "closed":
+			info += "  closed " + s.end.Format(models.DateLayout)
+		case s.overdue:
+			info += fmt.Sprintf("  due %s (%dd overdue)", s.due.Format(models.DateLayout), daysBetween(s.due, now))
+		case t.DueDate != nil:
+			info += "  due " + s.due.Format(models.DateLayout)
+		default:
+			info += "  no due date"
+		}
+		if ids := m.depGraph.Blockers(t.ID); len(ids) > 0 {
+			info += "  ← blocked by " + strings.Join(ids, ", ")
+		}
+	}
+	b.WriteString(ui.HelpDescStyle.Render(ui.Truncate(info, m.width)) + "\n")
+
+	b.WriteString(ui.HelpBarStyle.Render("j/k:select  h/l:pan  +/-:zoom  .:today  f:find bar  enter:details  T/esc:back"))
+	return b.String()
+}
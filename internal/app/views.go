@@ -2,8 +2,8 @@ package app
 
 import (
 	"fmt"
-	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -25,17 +25,27 @@ func (m Model) View() string {
 	case ViewForm:
 		return m.viewForm()
 	case ViewDetail:
-		if m.width < 80 || m.previousMode == ViewBoard {
-			// Narrow mode OR coming from board: full screen detail overlay
+		if m.width < 80 || m.previousMode != ViewList {
+			// Narrow mode OR coming from a full-screen view: detail overlay
 			return m.viewDetailOverlay()
 		}
 		return m.viewMain()
-	case ViewEditTitle, ViewEditStatus, ViewEditPriority, ViewEditType, ViewFilter, ViewAddBlocker, ViewRemoveBlocker, ViewEditText:
+	case ViewEditTitle, ViewEditStatus, ViewEditPriority, ViewEditType, ViewFilter, ViewAddBlocker, ViewRemoveBlocker, ViewEditText, ViewMoveParent, ViewPickTemplate, ViewEditLabels, ViewEditAssignee, ViewEditDate, ViewPickDepType, ViewSnooze, ViewCommandPrompt:
 		return m.viewMainWithModal()
 	case ViewAddComment:
 		return m.viewAddComment()
 	case ViewBoard:
 		return m.viewBoard()
+	case ViewGraph:
+		return m.viewGraph()
+	case ViewReport:
+		return m.viewReport()
+	case ViewCommandOutput:
+		return m.viewCommandOutput()
+	case ViewTimeline:
+		return m.viewTimeline()
+	case ViewCalendar:
+		return m.viewCalendar()
 	default:
 		return m.viewMain()
 	}
@@ -44,8 +54,14 @@ func (m Model) View() string {
 func (m Model) viewMain() string {
 	var b strings.Builder
 
+	// Config problems banner
+	if banner := m.renderConfigBanner(); banner != "" {
+		b.WriteString(banner)
+		b.WriteString("\n")
+	}
+
 	// Content area
-	contentHeight := m.height - 2
+	contentHeight := m.height - 2 - m.bannerHeight()
 
 	// Stack visible panels vertically
 	var panelViews []string
@@ -134,66 +150,8 @@ func (m *Model) viewHelp() string {
 
 	b.WriteString(ui.TitleStyle.Render("Keyboard Shortcuts") + "\n\n")
 
-	helpContent := `Navigation
-  j/k, ↑/↓    Move up/down in focused panel
-  g/G         Jump to top/bottom
-  ^u/^d       Page up/down
-
-Panels (h/l to cycle focus)
-  In Progress Tasks with status "in_progress"
-  Open        Tasks with status "open"
-  Closed      Tasks with status "closed"
-
-Views
-  b           Toggle board view (Kanban columns)
-
-Filtering
-  /           Start inline search in status bar
-  (typing)    Filter updates live as you type
-  enter       Confirm filter and return to navigation
-  esc         Clear filter and return to navigation
-  backspace   On empty input, exit search mode
-  o           Toggle open filter (open + in_progress)
-  O           Toggle closed filter (closed only)
-  r           Toggle ready filter (no blockers)
-  A           Clear all filters
-
-Actions
-  enter       View task details
-  a           Add new task
-  x           Delete selected task
-  R           Refresh list
-  S           Cycle sort mode (Default/Created/Priority/Updated)
-
-Field Editing
-  e           Edit title (modal)
-  s           Edit status (modal)
-  p           Edit priority (modal)
-  t           Edit type (modal)
-  y           Copy issue ID to clipboard
-  d           Edit description (modal)
-  n           Edit notes (modal)
-  C           Add comment
-  B           Add blocker (dependency)
-  D           Remove blocker
-
-General
-  ?           Toggle this help
-  q           Quit
-  esc         Back/cancel
-
-Auto-refresh: polls every 2 seconds
-`
-	// Add custom commands section if any are configured
-	if len(m.customCommands) > 0 {
-		helpContent += "\nCustom Commands\n"
-		for _, cmd := range m.customCommands {
-			helpContent += fmt.Sprintf("  %-10s  %s (%s)\n", cmd.Key, cmd.Description, cmd.Context)
-		}
-	}
-
 	// Set content on the viewport
-	m.helpViewport.SetContent(helpContent)
+	m.helpViewport.SetContent(m.helpContent())
 
 	// Render viewport inside overlay style
 	viewportContent := ui.OverlayStyle.
@@ -205,12 +163,149 @@ Auto-refresh: polls every 2 seconds
 
 	// Build status bar with scroll indicator
 	scrollInfo := fmt.Sprintf("%d%%", int(m.helpViewport.ScrollPercent()*100))
-	helpBar := fmt.Sprintf("j/k:scroll  ^u/^d:page  ?/esc:close  %s", scrollInfo)
+	helpBar := fmt.Sprintf("%s:scroll  %s:page  %s/%s:close  %s",
+		ui.HelpKey(m.keys.List.Up), ui.HelpKey(m.keys.List.PageUp),
+		ui.HelpKey(m.keys.List.Help), ui.HelpKey(m.keys.List.Cancel), scrollInfo)
 	b.WriteString(ui.HelpBarStyle.Render(helpBar))
 
 	return b.String()
 }
 
+// nonEmpty returns the non-empty strings among ss
+func nonEmpty(ss ...string) []string {
+	var out []string
+	for _, s := range ss {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// helpContent lists the effective keybindings, leaving out unbound ones
+func (m *Model) helpContent() string {
+	k, d := m.keys.List, m.keys.Detail
+	var help strings.Builder
+	// line writes a help entry; entries for unbound keys are left out
+	line := func(keys, desc string, more ...string) {
+		if keys == "" {
+			return
+		}
+		fmt.Fprintf(&help, "  %-11s %s\n", keys, desc)
+		for _, l := range more {
+			fmt.Fprintf(&help, "  %-11s %s\n", "", l)
+		}
+	}
+	section := func(title string) {
+		if help.Len() > 0 {
+			help.WriteString("\n")
+		}
+		help.WriteString(title + "\n")
+	}
+
+	section("Navigation")
+	line(ui.HelpKey(k.Up), "Move up/down in focused panel")
+	line(ui.HelpKey(k.Top), "Jump to top/bottom")
+	line(ui.HelpKey(k.PageUp), "Page up/down")
+
+	help.WriteString(`
+Row badges
+  ⊘           Blocked by another issue
+  ⟳           Part of a dependency cycle
+  »N          N open issues are waiting on this one
+  ███░░ 3/5   Closed descendants of a parent issue
+  ↑P0         Most urgent open child outranks the parent
+  ◷10-21      Earliest due date among open children
+  ↷10-21      Deferred; wakes up on that day
+  !3d         Overdue by 3 days (title shown in red)
+  ◷2d         Due within the due-soon window (2 days left)
+`)
+
+	section(fmt.Sprintf("Panels (%s to cycle focus)", ui.HelpKey(k.PrevView)))
+	help.WriteString(`  In Progress Tasks with status "in_progress"
+  Open        Tasks with status "open"
+  Closed      Tasks with status "closed"
+`)
+
+	section("Views")
+	line(ui.HelpKey(k.Board), "Toggle board view (Kanban columns)")
+	line(ui.HelpKey(k.Graph), "Dependency graph around the selected issue",
+		"(h/l follow edges, j/k move, enter re-centers, +/- depth)")
+	line(ui.HelpKey(k.Report), "Blocker analysis: critical path, bottlenecks, cycles")
+	line(ui.HelpKey(k.Timeline), "Timeline of issues grouped by epic",
+		"(h/l pan, +/- zoom day/week/month, . today, f find bar)")
+	line(ui.HelpKey(k.Calendar), "Calendar of due and defer dates with an overdue tray",
+		"(h/l day, j/k week, [/] period, w month/week, t tray,",
+		" tab issue, m move to another day)")
+
+	section("Filtering")
+	line(ui.HelpKey(k.Filter), "Start inline search in status bar")
+	line("(typing)", "Filter updates live as you type")
+	line("enter", "Confirm filter and return to navigation")
+	line(ui.HelpKey(k.Cancel), "Clear filter and return to navigation")
+	line("backspace", "On empty input, exit search mode")
+	line(ui.HelpKey(k.Open), "Toggle open filter (open + in_progress)")
+	line(ui.HelpKey(k.Closed), "Toggle closed filter (closed only)")
+	line(ui.HelpKey(k.Ready), "Toggle ready filter (no blockers)")
+	line(ui.HelpKey(k.Due), "Toggle due filter (overdue and due soon)")
+	line(ui.HelpKey(k.Deferred), "Toggle deferred filter (issues deferred to a later day,",
+		"which the other views hide)")
+	line(ui.HelpKey(k.All), "Clear all filters")
+
+	section("Actions")
+	line(ui.HelpKey(k.Select), "View task details",
+		fmt.Sprintf("(in details, %s/%s focus linked issues: parent,",
+			ui.HelpKey(d.Tab), ui.HelpKey(d.ShiftTab)),
+		fmt.Sprintf("children, blockers, dependents, related; %s opens the",
+			ui.HelpKey(d.Select)),
+		fmt.Sprintf("focused issue, %s and %s go back and forward)",
+			ui.HelpKey(d.HistoryBack), ui.HelpKey(d.HistoryForward)))
+	line(ui.HelpKey(k.Add), "Add new task (pick a template first, if configured)")
+	line(ui.HelpKey(k.AddChild), "Add child task under selected issue")
+	line(ui.HelpKey(k.Delete), "Delete selected task")
+	line(ui.HelpKey(k.Refresh), "Refresh list")
+	line(ui.HelpKey(k.Sort), "Cycle sort mode (Default/Created/Priority/Updated)")
+
+	section("Field Editing")
+	line(ui.HelpKey(k.EditTitle), "Edit title (modal)")
+	line(ui.HelpKey(k.EditStatus), "Edit status (modal)")
+	line(ui.HelpKey(k.EditPriority), "Edit priority (modal)")
+	line(ui.HelpKey(k.EditType), "Edit type (modal)")
+	line(ui.HelpKey(k.CopyID), "Copy issue ID to clipboard")
+	line(ui.HelpKey(k.EditDescription), "Edit description (modal)")
+	line(ui.HelpKey(k.EditNotes), "Edit notes (modal)")
+	line(ui.HelpKey(k.EditLabels), "Edit labels (add/remove with autocomplete)")
+	line(ui.HelpKey(k.EditAssignee), "Assign (pick from known people or type a name)")
+	line(ui.HelpKey(k.EditDueDate), "Set due date (YYYY-MM-DD, +3d, next fri, ...)")
+	line(ui.HelpKey(k.EditDeferDate), "Snooze: defer by a day, a week or until a date, or wake")
+	line(ui.HelpKey(k.AddComment), "Add comment")
+	line(ui.HelpKey(k.AddBlocker), "Add dependency (blocked by, blocks, related, discovered from,",
+		"child of); type to filter, links that would create a cycle",
+		"are shown disabled with the reason")
+	line(ui.HelpKey(k.RemoveBlocker), "Remove dependency")
+	line(ui.HelpKey(k.MoveParent), "Move under another parent (or detach to root)")
+
+	section("Multi-select")
+	line(ui.HelpKey(k.ToggleMark), "Mark/unmark issue (bulk actions apply to marked issues)")
+	line(ui.HelpKey(k.ClearMarks), "Clear all marks")
+
+	section("General")
+	line(ui.HelpKey(k.Help), "Toggle this help")
+	line(ui.HelpKey(k.Quit), "Quit")
+	line(ui.HelpKey(k.Cancel), "Back/cancel")
+
+	help.WriteString("\nAuto-refresh: polls every 2 seconds\n")
+
+	// Add custom commands section if any are configured
+	if len(m.customCommands) > 0 {
+		help.WriteString("\nCustom Commands\n")
+		for _, cmd := range m.customCommands {
+			fmt.Fprintf(&help, "  %-10s  %s (%s)\n", cmd.Key, cmd.Description, cmd.Context)
+		}
+	}
+	return help.String()
+}
+
 func (m Model) viewConfirm() string {
 	var b strings.Builder
 
@@ -255,6 +350,15 @@ func (m Model) renderStatusBar() string {
 		parts = append(parts, ui.SuccessStyle.Render(m.statusMsg))
 	}
 
+	// Show progress of an in-flight template run
+	if run := m.templateRun; run != nil {
+		progress := fmt.Sprintf("Creating from %q: %d/%d", run.name, run.done, len(run.steps))
+		if run.failure != nil {
+			progress = fmt.Sprintf("Rolling back %q…", run.name)
+		}
+		parts = append(parts, ui.HelpKeyStyle.Render(progress))
+	}
+
 	// When in search mode, show the search input
 	if m.searchMode {
 		// Search input with cursor
@@ -312,22 +416,47 @@ func (m Model) renderStatusBar() string {
 		// Minimal key bindings when filtering
 		parts = append(parts, ui.HelpKeyStyle.Render("esc")+":"+ui.HelpDescStyle.Render("clear"))
 	} else {
+		// Summary of due dates needing attention
+		if m.overdueCount > 0 {
+			parts = append(parts, lipgloss.NewStyle().Foreground(ui.ColorDanger).Bold(true).
+				Render(fmt.Sprintf("%d overdue", m.overdueCount)))
+		}
+		if m.dueSoonCount > 0 {
+			parts = append(parts, lipgloss.NewStyle().Foreground(ui.ColorWarning).
+				Render(fmt.Sprintf("%d due soon", m.dueSoonCount)))
+		}
+
 		// Default: show key bindings
-		keys := []struct {
+		type statusKey struct {
 			key  string
 			desc string
-		}{
-			{"enter", "detail"},
-			{"c", "create"},
-			{"e/s/p/t", "edit"},
-			{"d", "description"},
-			{"n", "notes"},
-			{"x", "delete"},
-			{"?", "help"},
-			{"q", "quit"},
+		}
+		k, d := m.keys.List, m.keys.Detail
+		edit := strings.Join(nonEmpty(ui.HelpKey(k.EditTitle), ui.HelpKey(k.EditStatus),
+			ui.HelpKey(k.EditPriority), ui.HelpKey(k.EditType)), "/")
+		keys := []statusKey{
+			{ui.HelpKey(k.Select), "detail"},
+			{ui.HelpKey(k.Add), "create"},
+			{edit, "edit"},
+			{ui.HelpKey(k.EditDescription), "description"},
+			{ui.HelpKey(k.EditNotes), "notes"},
+			{ui.HelpKey(k.Delete), "delete"},
+			{ui.HelpKey(k.Help), "help"},
+			{ui.HelpKey(k.Quit), "quit"},
+		}
+		if m.mode == ViewDetail {
+			keys = []statusKey{{ui.HelpKey(d.Tab), "linked issues"}, {ui.HelpKey(d.Select), "open"}}
+			if len(m.detailBack) > 0 || len(m.detailForward) > 0 {
+				history := fmt.Sprintf("back/forward (%d/%d)", len(m.detailBack), len(m.detailForward))
+				keys = append(keys, statusKey{ui.HelpKey(d.HistoryBack) + "/" + ui.HelpKey(d.HistoryForward), history})
+			}
+			keys = append(keys, statusKey{ui.HelpKey(d.Cancel), "close"})
 		}
 
 		for _, k := range keys {
+			if k.key == "" {
+				continue // unbound
+			}
 			part := ui.HelpKeyStyle.Render(k.key) + ":" + ui.HelpDescStyle.Render(k.desc)
 			parts = append(parts, part)
 		}
@@ -340,6 +469,14 @@ func (m Model) renderStatusBar() string {
 			parts = append(parts, filterPart)
 		}
 
+		// Deferred issues are left out of the panels; say how many
+		if m.deferredCount > 0 {
+			deferredPart := ui.HelpDescStyle.Render("[") +
+				ui.HelpKeyStyle.Render(fmt.Sprintf("%d deferred", m.deferredCount)) +
+				ui.HelpDescStyle.Render("]")
+			parts = append(parts, deferredPart)
+		}
+
 		// Show current sort mode if not default
 		if m.sortMode != SortDefault {
 			sortPart := ui.HelpDescStyle.Render("[") +
@@ -347,6 +484,14 @@ func (m Model) renderStatusBar() string {
 				ui.HelpDescStyle.Render("]")
 			parts = append(parts, sortPart)
 		}
+
+		// Show multi-selection count
+		if len(m.marked) > 0 {
+			markPart := ui.HelpDescStyle.Render("[") +
+				ui.HelpKeyStyle.Render(fmt.Sprintf("%d marked", len(m.marked))) +
+				ui.HelpDescStyle.Render("]")
+			parts = append(parts, markPart)
+		}
 	}
 
 	return strings.Join(parts, "  ")
@@ -387,6 +532,23 @@ func (m *Model) updateDetailContent() {
 	b.WriteString(ui.DetailValueStyle.Render(t.Type))
 	b.WriteString("\n")
 
+	if r, ok := m.rollups[t.ID]; ok {
+		b.WriteString(ui.DetailLabelStyle.Render("Progress:"))
+		b.WriteString(ui.SuccessStyle.Render(ui.ProgressBar(r.Done, r.Total, 20)))
+		b.WriteString(ui.DetailValueStyle.Render(fmt.Sprintf(" %d/%d closed (%d%%)", r.Done, r.Total, r.Percent())))
+		b.WriteString("\n")
+		if r.Priority >= 0 {
+			b.WriteString(ui.DetailLabelStyle.Render("Top child:"))
+			b.WriteString(ui.PriorityStyle(r.Priority).Render(fmt.Sprintf("P%d", r.Priority)))
+			b.WriteString("\n")
+		}
+		if r.Due != nil {
+			b.WriteString(ui.DetailLabelStyle.Render("Child due:"))
+			b.WriteString(ui.DetailValueStyle.Render(r.Due.Format("2006-01-02")))
+			b.WriteString("\n")
+		}
+	}
+
 	if t.Assignee != "" {
 		b.WriteString(ui.DetailLabelStyle.Render("Assignee:"))
 		b.WriteString(ui.DetailValueStyle.Render(t.Assignee))
@@ -414,6 +576,9 @@ func (m *Model) updateDetailContent() {
 	if t.DeferUntil != nil {
 		b.WriteString(ui.DetailLabelStyle.Render("Deferred:"))
 		b.WriteString(ui.DetailValueStyle.Render("until " + t.DeferUntil.Format("2006-01-02")))
+		if t.IsDeferred(time.Now()) {
+			b.WriteString(ui.HelpDescStyle.Render(" (" + wakeLabel(t, time.Now()) + ")"))
+		}
 		b.WriteString("\n")
 	}
 
@@ -476,91 +641,36 @@ func (m *Model) updateDetailContent() {
 		b.WriteString(renderedReason)
 	}
 
-	if len(t.BlockedBy) > 0 {
-		b.WriteString("\n")
-		b.WriteString(ui.DetailLabelStyle.Render("Blocked by:"))
-		b.WriteString("\n")
-		for _, id := range t.BlockedBy {
-			if linked, ok := m.tasksMap[id]; ok {
-				// Show priority, ID, title, and status
-				priority := ui.PriorityStyle(linked.Priority).Render(linked.PriorityString())
-				idStyled := ui.HelpDescStyle.Render(id)
-				status := ui.StatusStyle(linked.Status).Render("[" + linked.Status + "]")
-				b.WriteString(fmt.Sprintf("  %s %s %s %s\n", priority, idStyled, linked.Title, status))
-			} else {
-				// Fallback: just show ID if task not in memory
-				b.WriteString("  - " + id + "\n")
-			}
+	// Linked issues grouped by relationship type
+	focusedLink := m.focusedDetailLink()
+	focusMarked := false
+	for _, group := range m.linkGroups(t) {
+		if len(group.ids) == 0 {
+			continue
 		}
-	}
-
-	if len(t.Blocks) > 0 {
 		b.WriteString("\n")
-		b.WriteString(ui.DetailLabelStyle.Render("Blocks:"))
+		b.WriteString(ui.DetailLabelStyle.UnsetWidth().Render(group.label))
 		b.WriteString("\n")
-		for _, id := range t.Blocks {
+		for _, id := range group.ids {
+			prefix := "  "
+			if id == focusedLink && !focusMarked {
+				prefix = ui.HelpKeyStyle.Render("▸ ")
+				m.detailLinkLine = strings.Count(b.String(), "\n")
+				focusMarked = true
+			}
 			if linked, ok := m.tasksMap[id]; ok {
 				// Show priority, ID, title, and status
 				priority := ui.PriorityStyle(linked.Priority).Render(linked.PriorityString())
 				idStyled := ui.HelpDescStyle.Render(id)
 				status := ui.StatusStyle(linked.Status).Render("[" + linked.Status + "]")
-				b.WriteString(fmt.Sprintf("  %s %s %s %s\n", priority, idStyled, linked.Title, status))
+				b.WriteString(fmt.Sprintf("%s%s %s %s %s\n", prefix, priority, idStyled, linked.Title, status))
 			} else {
 				// Fallback: just show ID if task not in memory
-				b.WriteString("  - " + id + "\n")
+				b.WriteString(prefix + "- " + id + "\n")
 			}
 		}
 	}
 
-	// Parent/Children hierarchy
-	// Uses explicit parent-child dependencies first, falls back to ID naming convention.
-	b.WriteString("\n")
-	parentID := t.GetParentID()
-	b.WriteString(ui.DetailLabelStyle.Render("Parent:"))
-	if parentID != "" {
-		if parent, ok := m.tasksMap[parentID]; ok {
-			priority := ui.PriorityStyle(parent.Priority).Render(parent.PriorityString())
-			idStyled := ui.HelpDescStyle.Render(parentID)
-			status := ui.StatusStyle(parent.Status).Render("[" + parent.Status + "]")
-			b.WriteString(fmt.Sprintf(" %s %s %s %s", priority, idStyled, parent.Title, status))
-		} else {
-			b.WriteString(ui.DetailValueStyle.Render(parentID))
-		}
-	}
-	b.WriteString("\n")
-
-	b.WriteString(ui.DetailLabelStyle.Render("Children:"))
-	b.WriteString("\n")
-	childIDs := make(map[string]bool)
-	var children []*models.Task
-	// Find children via explicit parent-child deps (scan all tasks)
-	for _, task := range m.tasksMap {
-		for _, dep := range task.Dependencies {
-			if dep.IsParentChild() && dep.DependsOnID == t.ID {
-				if !childIDs[task.ID] {
-					childIDs[task.ID] = true
-					children = append(children, task)
-				}
-			}
-		}
-	}
-	// Also find children via ID naming convention
-	for id, task := range m.tasksMap {
-		if !childIDs[id] && models.IsDirectChildOf(id, t.ID) {
-			childIDs[id] = true
-			children = append(children, task)
-		}
-	}
-	sort.Slice(children, func(i, j int) bool {
-		return children[i].ID < children[j].ID
-	})
-	for _, child := range children {
-		priority := ui.PriorityStyle(child.Priority).Render(child.PriorityString())
-		idStyled := ui.HelpDescStyle.Render(child.ID)
-		status := ui.StatusStyle(child.Status).Render("[" + child.Status + "]")
-		b.WriteString(fmt.Sprintf("  %s %s %s %s\n", priority, idStyled, child.Title, status))
-	}
-
 	// Timestamps section
 	b.WriteString("\n")
 	b.WriteString(ui.DetailLabelStyle.Render("Created:"))
@@ -608,6 +718,13 @@ func (m Model) viewForm() string {
 
 	if m.editing {
 		b.WriteString(ui.TitleStyle.Render("Edit Task") + "\n\n")
+	} else if m.formParentID != "" {
+		b.WriteString(ui.TitleStyle.Render("New Child Task") + "\n")
+		parentLabel := m.formParentID
+		if parent, ok := m.tasksMap[m.formParentID]; ok {
+			parentLabel += " " + parent.Title
+		}
+		b.WriteString(ui.HelpDescStyle.Render("Parent: "+parentLabel) + "\n\n")
 	} else {
 		b.WriteString(ui.TitleStyle.Render("New Task") + "\n\n")
 	}
@@ -663,6 +780,15 @@ func (m Model) viewForm() string {
 	}
 	b.WriteString(typeLabel + typeValue + focusIndicator + "\n\n")
 
+	// Labels and children come from the template, if one was chosen
+	if len(m.formLabels) > 0 {
+		b.WriteString(ui.FormLabelStyle.Render("Labels:") + ui.HelpDescStyle.Render(strings.Join(m.formLabels, ", ")) + "\n\n")
+	}
+	if m.formTemplate != nil && len(m.formTemplate.Children) > 0 {
+		b.WriteString(ui.FormLabelStyle.Render("Children:") +
+			ui.HelpDescStyle.Render(fmt.Sprintf("%d issue(s) from template %q", len(m.formTemplate.Children), m.formTemplate.Name)) + "\n\n")
+	}
+
 	// Help
 	b.WriteString("\n")
 	b.WriteString(ui.HelpBarStyle.Render("tab/shift+tab: next/prev field  enter: submit  esc: cancel"))
@@ -677,14 +803,8 @@ func (m Model) viewBoard() string {
 	const totalColumns = 5
 	const minColWidth = 30
 
-	// Column border colors
-	columnColors := [totalColumns]lipgloss.Color{
-		lipgloss.Color("1"), // Red - Blocked
-		lipgloss.Color("7"), // White - Open
-		lipgloss.Color("2"), // Green - Ready
-		lipgloss.Color("3"), // Yellow - In Progress
-		lipgloss.Color("6"), // Cyan - Done
-	}
+	// Column border colors from the theme
+	columnColors := ui.BoardColumnColors
 	columnHeaders := [totalColumns]string{"BLOCKED", "OPEN", "READY", "IN PROGRESS", "DONE"}
 
 	// Get tasks categorized into 5 columns
@@ -775,10 +895,28 @@ func (m Model) viewBoard() string {
 	// Render a single task card (3 lines, no borders)
 	// Returns 3 lines of content, each padded to innerWidth
 	renderCard := func(bt boardTask, selected bool, innerWidth int) string {
-		// Line 1: Priority + ID
+		// Line 1: Priority + ID, then child progress for parents
 		priority := ui.PriorityStyle(bt.task.Priority).Render(bt.priority)
 		idStyled := ui.HelpDescStyle.Render(bt.id)
 		line1 := priority + " " + idStyled
+		rollup := rollupSegments(bt.task, m.rollups[bt.id])
+		var progress, progressPlain string
+		var rollupMeta, rollupMetaPlain []string
+		for i, seg := range rollup {
+			if i == 0 {
+				progress, progressPlain = seg.style.Render(seg.text), seg.text
+				continue
+			}
+			rollupMeta = append(rollupMeta, seg.style.Render(seg.text))
+			rollupMetaPlain = append(rollupMetaPlain, seg.text)
+		}
+		// Rollup badges are dropped rather than wrapped on narrow columns
+		if lipgloss.Width(bt.priority+" "+bt.id+"  "+progressPlain) >= innerWidth {
+			progress, progressPlain = "", ""
+		}
+		if progress != "" {
+			line1 += "  " + progress
+		}
 
 		// Line 2: Title (full width)
 		title := truncateToWidth(bt.title, innerWidth)
@@ -791,18 +929,30 @@ func (m Model) viewBoard() string {
 			assigneeStyled := lipgloss.NewStyle().Foreground(ui.ColorAccent).Render("@" + bt.task.Assignee)
 			line3 = typeStyled + "  " + assigneeStyled
 		}
+		if len(rollupMeta) > 0 && lipgloss.Width(line3+"  "+strings.Join(rollupMetaPlain, " ")) < innerWidth {
+			line3 += "  " + strings.Join(rollupMeta, " ")
+		} else {
+			rollupMetaPlain = nil
+		}
 
 		if selected {
 			highlightStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color("236")).
-				Foreground(lipgloss.Color("15"))
-			line1 = highlightStyle.Render(padToWidth("▸"+priority+" "+bt.id, innerWidth))
+				Background(ui.ColorHighlight).
+				Foreground(ui.ColorHighlightText)
+			head := "▸" + priority + " " + bt.id
+			if progressPlain != "" {
+				head += "  " + progressPlain
+			}
+			line1 = highlightStyle.Render(padToWidth(head, innerWidth))
 			line2 = highlightStyle.Render(padToWidth("▸"+truncateToWidth(bt.title, innerWidth-1), innerWidth))
 			// Line 3 for selected: re-render plain text with highlight
 			meta := "▸" + bt.task.Type
 			if bt.task.Assignee != "" {
 				meta += "  @" + bt.task.Assignee
 			}
+			if len(rollupMetaPlain) > 0 {
+				meta += "  " + strings.Join(rollupMetaPlain, " ")
+			}
 			line3 = highlightStyle.Render(padToWidth(meta, innerWidth))
 		} else {
 			line1 = padToWidth(line1, innerWidth)
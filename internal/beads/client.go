@@ -159,6 +159,7 @@ type CreateOptions struct {
 	Type        string // task, bug, feature, epic, chore
 	Priority    int    // 0-4
 	Labels      []string
+	Parent      string // parent issue ID (creates a parent-child dependency)
 }
 
 // Create creates a new task
@@ -177,6 +178,9 @@ func (c *Client) Create(opts CreateOptions) (*models.Task, error) {
 	if len(opts.Labels) > 0 {
 		args = append(args, "-l", strings.Join(opts.Labels, ","))
 	}
+	if opts.Parent != "" {
+		args = append(args, "--parent", opts.Parent)
+	}
 
 	out, err := runBD(args...)
 	if err != nil {
@@ -209,13 +213,17 @@ func (c *Client) Create(opts CreateOptions) (*models.Task, error) {
 
 // UpdateOptions holds options for updating a task
 type UpdateOptions struct {
-	Status      string
-	Priority    *int
-	Title       string
-	Assignee    string
-	Type        string
-	Description string
-	Notes       string
+	Status       string
+	Priority     *int
+	Title        string
+	Assignee     string
+	Type         string
+	Description  string
+	Notes        string
+	AddLabels    []string
+	RemoveLabels []string
+	DueDate      *string // YYYY-MM-DD; empty string clears the due date
+	DeferUntil   *string // YYYY-MM-DD; empty string clears the defer date
 }
 
 // Update modifies an existing task
@@ -243,6 +251,18 @@ func (c *Client) Update(id string, opts UpdateOptions) error {
 	if opts.Notes != "" {
 		args = append(args, "--notes", opts.Notes)
 	}
+	for _, label := range opts.AddLabels {
+		args = append(args, "--add-label", label)
+	}
+	for _, label := range opts.RemoveLabels {
+		args = append(args, "--remove-label", label)
+	}
+	if opts.DueDate != nil {
+		args = append(args, "--due", *opts.DueDate)
+	}
+	if opts.DeferUntil != nil {
+		args = append(args, "--defer", *opts.DeferUntil)
+	}
 
 	_, err := runBD(args...)
 	return err
@@ -319,3 +339,20 @@ func (c *Client) RemoveBlocker(blockee string, blocker string) error {
 	_, err := runBD("dep", "rm", blockee, blocker)
 	return err
 }
+
+// AddDependency adds a dependency of the given type (e.g. "parent-child")
+// where issueID depends on dependsOnID
+func (c *Client) AddDependency(issueID, dependsOnID, depType string) error {
+	args := []string{"dep", "add", issueID, dependsOnID}
+	if depType != "" {
+		args = append(args, "--type", depType)
+	}
+	_, err := runBD(args...)
+	return err
+}
+
+// RemoveDependency removes any dependency of issueID on dependsOnID
+func (c *Client) RemoveDependency(issueID, dependsOnID string) error {
+	_, err := runBD("dep", "rm", issueID, dependsOnID)
+	return err
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,6 +12,103 @@ import (
 // Config represents the application configuration
 type Config struct {
 	CustomCommands []CustomCommand `yaml:"customCommands"`
+	Templates      []IssueTemplate `yaml:"templates"`
+	Due            DueConfig       `yaml:"due"`
+	Theme          ThemeConfig     `yaml:"theme"`
+
+	// Keybindings remaps built-in bindings per context (list, detail,
+	// board, form, modal): context -> binding name -> keys
+	Keybindings map[string]map[string]KeyList `yaml:"keybindings"`
+
+	// Sources are the files the config was read from, in the order they
+	// were layered (user config first, then the repository's)
+	Sources []string `yaml:"-"`
+
+	// Origins maps each setting to where it was set. Settings are named
+	// like their YAML path, with commands keyed by key and templates by
+	// name: "customCommands.t", "templates.Bug report", "theme.colors.primary".
+	Origins map[string]Location `yaml:"-"`
+
+	// Problems found while loading; the settings at fault are ignored or
+	// fall back to their defaults
+	Problems []Problem `yaml:"-"`
+
+	lines map[string]int // line of each setting in a single file
+}
+
+// KeyList is the keys of a binding. A single key may be given as a plain
+// string; an empty string or list unbinds it.
+type KeyList []string
+
+// UnmarshalYAML accepts either a single key or a list of keys
+func (k *KeyList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var single string
+		if err := value.Decode(&single); err != nil {
+			return err
+		}
+		*k = nil
+		if single != "" {
+			*k = KeyList{single}
+		}
+		return nil
+	}
+	return value.Decode((*[]string)(k))
+}
+
+// KeyOverrides returns the keybinding overrides as plain key lists
+func (c *Config) KeyOverrides() map[string]map[string][]string {
+	overrides := make(map[string]map[string][]string, len(c.Keybindings))
+	for context, bindings := range c.Keybindings {
+		overrides[context] = make(map[string][]string, len(bindings))
+		for name, keys := range bindings {
+			overrides[context][name] = keys
+		}
+	}
+	return overrides
+}
+
+// ThemeConfig selects a built-in theme and overrides individual colors.
+// A plain string is shorthand for the theme name:
+//
+//	theme: light
+type ThemeConfig struct {
+	Name   string            `yaml:"name"`   // dark (default), light, high-contrast, solarized
+	Colors map[string]string `yaml:"colors"` // color name -> ANSI color number or #rrggbb
+}
+
+// UnmarshalYAML accepts either a theme name or a mapping
+func (t *ThemeConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&t.Name)
+	}
+	type plain ThemeConfig
+	return value.Decode((*plain)(t))
+}
+
+// Due date highlighting defaults
+const (
+	DefaultDueSoonDays  = 3
+	DefaultDueGraceDays = 0
+)
+
+// DueConfig controls when issues are highlighted as due soon or overdue
+type DueConfig struct {
+	SoonDays  *int `yaml:"soonDays"`  // flag open issues due within this many days
+	GraceDays *int `yaml:"graceDays"` // days past the due date before an issue counts as overdue
+}
+
+// Thresholds returns the due-soon window and the overdue grace period in
+// days, using the defaults for unset or negative values
+func (d DueConfig) Thresholds() (soon, grace int) {
+	soon, grace = DefaultDueSoonDays, DefaultDueGraceDays
+	if d.SoonDays != nil && *d.SoonDays >= 0 {
+		soon = *d.SoonDays
+	}
+	if d.GraceDays != nil && *d.GraceDays >= 0 {
+		grace = *d.GraceDays
+	}
+	return soon, grace
 }
 
 // CustomCommand represents a user-defined command
@@ -18,15 +117,82 @@ type CustomCommand struct {
 	Description string `yaml:"description"`
 	Context     string `yaml:"context"` // list, detail, or global
 	Command     string `yaml:"command"`
+	Mode        string `yaml:"mode"`      // background (default), capture, interactive, or patch
+	Selection   string `yaml:"selection"` // single (default), each, or all marked issues
+
+	// Prompts are asked in order before the command runs; the answers are
+	// available to the command template as .Prompt.<name>
+	Prompts []CommandPrompt `yaml:"prompts"`
+}
+
+// CommandPrompt asks for a value before a custom command runs
+type CommandPrompt struct {
+	Name    string   `yaml:"name"`
+	Type    string   `yaml:"type"`    // text (default), choice, confirm, or issue
+	Title   string   `yaml:"title"`   // question shown to the user; defaults to the name
+	Default string   `yaml:"default"` // initial text, choice or confirm answer ("true"/"false")
+	Options []string `yaml:"options"` // choices for the choice type
+}
+
+// IssueTemplate pre-fills the create form. Text fields are Go templates
+// rendered with .ParentID, .Date and .Type.
+type IssueTemplate struct {
+	Name        string          `yaml:"name"`
+	Type        string          `yaml:"type"`        // task, bug, feature, epic, chore
+	TitlePrefix string          `yaml:"titlePrefix"` // initial title text
+	Description string          `yaml:"description"` // description skeleton
+	Labels      []string        `yaml:"labels"`
+	Priority    *int            `yaml:"priority"` // 0-4, form default when unset
+	Children    []TemplateChild `yaml:"children"` // issues created under the new issue
 }
 
-// Load reads the configuration from the default location
+// TemplateChild is an issue created under the issue made from a template.
+// Text fields are Go templates; .ParentID is the new parent issue's ID and
+// .Title its title. Children may nest, and DependsOn lists the IDs of other
+// children in the same template that block this one.
+type TemplateChild struct {
+	ID          string          `yaml:"id"` // local reference for dependsOn
+	Title       string          `yaml:"title"`
+	Type        string          `yaml:"type"`
+	Description string          `yaml:"description"`
+	Labels      []string        `yaml:"labels"`
+	Priority    *int            `yaml:"priority"`
+	DependsOn   []string        `yaml:"dependsOn"`
+	Children    []TemplateChild `yaml:"children"`
+}
+
+// Load reads the user configuration and layers the repository's
+// configuration, if any, over it (see merge for the precedence rules)
 func Load() (*Config, error) {
-	configPath := ConfigPath()
+	cfg := &Config{Origins: make(map[string]Location)}
+
+	paths := []string{ConfigPath()}
+	if repoPath := RepoConfigPath(); repoPath != "" {
+		paths = append(paths, repoPath)
+	}
+	for _, path := range paths {
+		layer, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if layer == nil {
+			continue
+		}
+		cfg.Sources = append(cfg.Sources, path)
+		cfg.merge(layer, path)
+	}
+
+	SortProblems(cfg.Problems)
+	return cfg, nil
+}
 
-	// If config file doesn't exist, return empty config
+// loadFile reads a single config file, returning nil if it does not exist.
+// Syntax errors, type mismatches and invalid settings are recorded in
+// Problems rather than failing the load.
+func loadFile(configPath string) (*Config, error) {
+	// If config file doesn't exist, there is nothing to layer
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return &Config{}, nil
+		return nil, nil
 	}
 
 	data, err := os.ReadFile(configPath)
@@ -35,20 +201,74 @@ func Load() (*Config, error) {
 	}
 
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, err
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		cfg.Problems = append(cfg.Problems, syntaxProblem(configPath, err))
+		return &cfg, nil
+	}
+	if len(root.Content) == 0 {
+		return &cfg, nil // empty file
+	}
+	doc := root.Content[0]
+
+	if err := doc.Decode(&cfg); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			cfg.Problems = append(cfg.Problems, syntaxProblem(configPath, err))
+			return &cfg, nil
+		}
+		cfg.Problems = append(cfg.Problems, typeErrorProblems(configPath, typeErr)...)
 	}
+	cfg.Problems = append(cfg.Problems, unknownFields(configPath, doc, reflect.TypeOf(cfg))...)
+	cfg.Problems = append(cfg.Problems, cfg.check(configPath, doc)...)
+	cfg.lines = index(doc)
 
 	// Set defaults for context if not specified
 	for i := range cfg.CustomCommands {
 		if cfg.CustomCommands[i].Context == "" {
 			cfg.CustomCommands[i].Context = "list"
 		}
+		if cfg.CustomCommands[i].Mode == "" {
+			cfg.CustomCommands[i].Mode = "background"
+		}
+		if cfg.CustomCommands[i].Selection == "" {
+			cfg.CustomCommands[i].Selection = "single"
+		}
+		for j := range cfg.CustomCommands[i].Prompts {
+			p := &cfg.CustomCommands[i].Prompts[j]
+			if p.Type == "" {
+				p.Type = "text"
+			}
+			if p.Title == "" {
+				p.Title = p.Name
+			}
+		}
+	}
+
+	// Templates default to the task type and are named after their type
+	for i := range cfg.Templates {
+		if cfg.Templates[i].Type == "" {
+			cfg.Templates[i].Type = "task"
+		}
+		if cfg.Templates[i].Name == "" {
+			cfg.Templates[i].Name = cfg.Templates[i].Type
+		}
+		setChildDefaults(cfg.Templates[i].Children)
 	}
 
 	return &cfg, nil
 }
 
+// setChildDefaults defaults the type of template children to task
+func setChildDefaults(children []TemplateChild) {
+	for i := range children {
+		if children[i].Type == "" {
+			children[i].Type = "task"
+		}
+		setChildDefaults(children[i].Children)
+	}
+}
+
 // ConfigPath returns the config file path to use.
 // It checks in order:
 //  1. BB_CONFIG environment variable (direct path to config file)
@@ -62,6 +282,58 @@ func ConfigPath() string {
 	return DefaultConfigPath()
 }
 
+// RepoConfigFiles are the per-repository config files, relative to the
+// repository root, in order of preference
+var RepoConfigFiles = []string{
+	filepath.Join(".beads", "bb.yml"),
+	".bb.yml",
+}
+
+// RepoRoot returns the repository bb runs in: the nearest directory, from
+// the current one up, that contains .beads, as bd finds it. Outside a
+// repository it is the current directory.
+func RepoRoot() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := cwd; ; {
+		if info, err := os.Stat(filepath.Join(dir, ".beads")); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return cwd
+		}
+		dir = parent
+	}
+}
+
+// RepoConfigPath returns the config file of the repository bb runs in, or
+// "" if there is none. Only the first of RepoConfigFiles that exists is
+// used.
+func RepoConfigPath() string {
+	root := RepoRoot()
+	for _, name := range RepoConfigFiles {
+		path := filepath.Join(root, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// WatchPaths returns every file Load may read: the user config and each
+// candidate repository config, whether or not they exist yet
+func WatchPaths() []string {
+	paths := []string{ConfigPath()}
+	root := RepoRoot()
+	for _, name := range RepoConfigFiles {
+		paths = append(paths, filepath.Join(root, name))
+	}
+	return paths
+}
+
 // DefaultConfigPath returns the default config file path
 func DefaultConfigPath() string {
 	// Check XDG_CONFIG_HOME first
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -179,4 +181,576 @@ func TestDefaultContext(t *testing.T) {
 	if cfg.CustomCommands[0].Context != "list" {
 		t.Errorf("expected default context to be 'list', got '%s'", cfg.CustomCommands[0].Context)
 	}
+
+	// Should default to "background" for the selected issue only
+	if cfg.CustomCommands[0].Mode != "background" {
+		t.Errorf("expected default mode to be 'background', got '%s'", cfg.CustomCommands[0].Mode)
+	}
+	if cfg.CustomCommands[0].Selection != "single" {
+		t.Errorf("expected default selection to be 'single', got '%s'", cfg.CustomCommands[0].Selection)
+	}
+}
+
+func TestCustomCommandModes(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	configContent := `customCommands:
+  - key: T
+    description: Run tests
+    command: go test ./...
+    mode: capture
+    selection: each
+  - key: E
+    description: Edit
+    command: vi
+    mode: foreground
+    selection: some
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("BB_CONFIG", configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.CustomCommands[0].Mode != "capture" {
+		t.Errorf("expected mode 'capture', got '%s'", cfg.CustomCommands[0].Mode)
+	}
+	if cfg.CustomCommands[0].Selection != "each" {
+		t.Errorf("expected selection 'each', got '%s'", cfg.CustomCommands[0].Selection)
+	}
+	if len(cfg.Problems) != 2 ||
+		cfg.Problems[0].Line != 10 || !strings.Contains(cfg.Problems[0].Message, `unknown mode "foreground"`) ||
+		cfg.Problems[1].Line != 11 || !strings.Contains(cfg.Problems[1].Message, `unknown selection "some"`) {
+		t.Errorf("expected unknown mode and selection problems at lines 10 and 11, got %v", cfg.Problems)
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "bb"), 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+
+	configContent := `templates:
+  - name: "Bug report"
+    type: bug
+    titlePrefix: "[bug] "
+    description: |
+      ## Steps to reproduce
+
+      ## Expected
+    labels: [triage]
+    priority: 1
+    children:
+      - title: "Regression test for {{.ParentID}}"
+  - type: feature
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "bb", "config.yml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	originalUserConfigDir := os.Getenv("XDG_CONFIG_HOME")
+	os.Setenv("XDG_CONFIG_HOME", tmpDir)
+	defer os.Setenv("XDG_CONFIG_HOME", originalUserConfigDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(cfg.Templates) != 2 {
+		t.Fatalf("expected 2 templates, got %d", len(cfg.Templates))
+	}
+
+	bug := cfg.Templates[0]
+	if bug.Name != "Bug report" || bug.Type != "bug" || bug.TitlePrefix != "[bug] " {
+		t.Errorf("unexpected bug template: %+v", bug)
+	}
+	if bug.Priority == nil || *bug.Priority != 1 {
+		t.Errorf("expected bug template priority 1, got %v", bug.Priority)
+	}
+	if len(bug.Labels) != 1 || bug.Labels[0] != "triage" {
+		t.Errorf("expected labels [triage], got %v", bug.Labels)
+	}
+	if len(bug.Children) != 1 || bug.Children[0].Type != "task" {
+		t.Errorf("expected one child defaulting to type 'task', got %+v", bug.Children)
+	}
+
+	// Name defaults to the type
+	if cfg.Templates[1].Name != "feature" {
+		t.Errorf("expected template name to default to 'feature', got '%s'", cfg.Templates[1].Name)
+	}
+	if cfg.Templates[1].Priority != nil {
+		t.Errorf("expected unset priority, got %v", *cfg.Templates[1].Priority)
+	}
+}
+
+func TestLoadTemplateTree(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "bb"), 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+
+	configContent := `templates:
+  - name: release
+    type: epic
+    titlePrefix: "Release {{.Date}}"
+    children:
+      - id: freeze
+        title: Code freeze
+      - id: notes
+        title: Release notes
+        dependsOn: [freeze]
+        children:
+          - title: Draft changelog
+            type: chore
+          - title: Review changelog
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "bb", "config.yml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	originalUserConfigDir := os.Getenv("XDG_CONFIG_HOME")
+	os.Setenv("XDG_CONFIG_HOME", tmpDir)
+	defer os.Setenv("XDG_CONFIG_HOME", originalUserConfigDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(cfg.Templates) != 1 || len(cfg.Templates[0].Children) != 2 {
+		t.Fatalf("expected 1 template with 2 children, got %+v", cfg.Templates)
+	}
+
+	notes := cfg.Templates[0].Children[1]
+	if notes.ID != "notes" || len(notes.DependsOn) != 1 || notes.DependsOn[0] != "freeze" {
+		t.Errorf("unexpected notes child: %+v", notes)
+	}
+	if len(notes.Children) != 2 {
+		t.Fatalf("expected 2 nested children, got %d", len(notes.Children))
+	}
+	if notes.Children[0].Type != "chore" {
+		t.Errorf("expected nested child type 'chore', got '%s'", notes.Children[0].Type)
+	}
+	if notes.Children[1].Type != "task" {
+		t.Errorf("expected nested child type to default to 'task', got '%s'", notes.Children[1].Type)
+	}
+}
+
+func TestDueThresholds(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "bb"), 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+
+	configContent := `due:
+  soonDays: 7
+  graceDays: 1
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "bb", "config.yml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	originalUserConfigDir := os.Getenv("XDG_CONFIG_HOME")
+	os.Setenv("XDG_CONFIG_HOME", tmpDir)
+	defer os.Setenv("XDG_CONFIG_HOME", originalUserConfigDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if soon, grace := cfg.Due.Thresholds(); soon != 7 || grace != 1 {
+		t.Errorf("expected thresholds 7/1, got %d/%d", soon, grace)
+	}
+
+	// Unset and negative values fall back to the defaults
+	negative := -2
+	due := DueConfig{GraceDays: &negative}
+	if soon, grace := due.Thresholds(); soon != DefaultDueSoonDays || grace != DefaultDueGraceDays {
+		t.Errorf("expected default thresholds, got %d/%d", soon, grace)
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantName   string
+		wantColors map[string]string
+	}{
+		{
+			name:     "shorthand",
+			content:  "theme: solarized\n",
+			wantName: "solarized",
+		},
+		{
+			name: "mapping",
+			content: `theme:
+  name: light
+  colors:
+    primary: "#00875a"
+    selection: "153"
+`,
+			wantName:   "light",
+			wantColors: map[string]string{"primary": "#00875a", "selection": "153"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+			t.Setenv("BB_CONFIG", configPath)
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+			if cfg.Theme.Name != tt.wantName {
+				t.Errorf("expected theme %q, got %q", tt.wantName, cfg.Theme.Name)
+			}
+			if len(cfg.Theme.Colors) != len(tt.wantColors) {
+				t.Fatalf("expected %d colors, got %v", len(tt.wantColors), cfg.Theme.Colors)
+			}
+			for k, v := range tt.wantColors {
+				if cfg.Theme.Colors[k] != v {
+					t.Errorf("expected color %s=%q, got %q", k, v, cfg.Theme.Colors[k])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadKeybindings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	configContent := `keybindings:
+  list:
+    editStatus: S
+    delete: [X, ctrl+x]
+    sort: ""
+  modal:
+    select: []
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("BB_CONFIG", configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	overrides := cfg.KeyOverrides()
+	tests := []struct {
+		context, name string
+		want          []string
+	}{
+		{"list", "editStatus", []string{"S"}},
+		{"list", "delete", []string{"X", "ctrl+x"}},
+		{"list", "sort", nil},
+		{"modal", "select", nil},
+	}
+	for _, tt := range tests {
+		keys, ok := overrides[tt.context][tt.name]
+		if !ok {
+			t.Errorf("%s.%s: missing override", tt.context, tt.name)
+			continue
+		}
+		if len(keys) != len(tt.want) {
+			t.Errorf("%s.%s: expected %v, got %v", tt.context, tt.name, tt.want, keys)
+			continue
+		}
+		for i := range keys {
+			if keys[i] != tt.want[i] {
+				t.Errorf("%s.%s: expected %v, got %v", tt.context, tt.name, tt.want, keys)
+			}
+		}
+	}
+}
+
+func TestLoadRepoConfig(t *testing.T) {
+	userDir := t.TempDir()
+	userPath := filepath.Join(userDir, "config.yml")
+	userContent := `customCommands:
+  - key: "t"
+    description: "User tests"
+    command: "make test"
+  - key: "o"
+    description: "Open"
+    command: "open {{.ID}}"
+templates:
+  - name: Bug
+    type: bug
+theme:
+  name: light
+  colors:
+    primary: "2"
+    danger: "1"
+due:
+  soonDays: 5
+keybindings:
+  list:
+    editStatus: S
+`
+	if err := os.WriteFile(userPath, []byte(userContent), 0644); err != nil {
+		t.Fatalf("failed to write user config: %v", err)
+	}
+	t.Setenv("BB_CONFIG", userPath)
+
+	repoDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoDir, ".beads"), 0755); err != nil {
+		t.Fatalf("failed to create .beads: %v", err)
+	}
+	repoContent := `customCommands:
+  - key: "t"
+    description: "Repo tests"
+    command: "go test ./..."
+templates:
+  - name: Release
+    type: epic
+theme:
+  colors:
+    primary: "5"
+due:
+  graceDays: 2
+keybindings:
+  list:
+    sort: ""
+`
+	if err := os.WriteFile(filepath.Join(repoDir, ".beads", "bb.yml"), []byte(repoContent), 0644); err != nil {
+		t.Fatalf("failed to write repo config: %v", err)
+	}
+	// .beads/bb.yml takes precedence over .bb.yml
+	if err := os.WriteFile(filepath.Join(repoDir, ".bb.yml"), []byte("theme: solarized\n"), 0644); err != nil {
+		t.Fatalf("failed to write repo config: %v", err)
+	}
+	t.Chdir(repoDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	repoPath := RepoConfigPath()
+	if filepath.Base(repoPath) != "bb.yml" {
+		t.Fatalf("expected .beads/bb.yml to be used, got %q", repoPath)
+	}
+	if len(cfg.Sources) != 2 || cfg.Sources[0] != userPath || cfg.Sources[1] != repoPath {
+		t.Errorf("expected sources [%s %s], got %v", userPath, repoPath, cfg.Sources)
+	}
+
+	// Repo command replaces the user command on the same key
+	if len(cfg.CustomCommands) != 2 {
+		t.Fatalf("expected 2 custom commands, got %d", len(cfg.CustomCommands))
+	}
+	for _, cmd := range cfg.CustomCommands {
+		if cmd.Key == "t" && cmd.Description != "Repo tests" {
+			t.Errorf("expected repo command for t, got %q", cmd.Description)
+		}
+	}
+	if len(cfg.Templates) != 2 {
+		t.Errorf("expected 2 templates, got %d", len(cfg.Templates))
+	}
+
+	// Settings are overridden one at a time
+	if cfg.Theme.Name != "light" {
+		t.Errorf("expected theme light, got %q", cfg.Theme.Name)
+	}
+	if cfg.Theme.Colors["primary"] != "5" || cfg.Theme.Colors["danger"] != "1" {
+		t.Errorf("unexpected theme colors %v", cfg.Theme.Colors)
+	}
+	if soon, grace := cfg.Due.Thresholds(); soon != 5 || grace != 2 {
+		t.Errorf("expected thresholds 5/2, got %d/%d", soon, grace)
+	}
+	if len(cfg.Keybindings["list"]) != 2 {
+		t.Errorf("expected 2 list keybindings, got %v", cfg.Keybindings["list"])
+	}
+
+	origins := map[string]string{
+		"customCommands.t":            repoPath,
+		"customCommands.o":            userPath,
+		"templates.Bug":               userPath,
+		"templates.Release":           repoPath,
+		"theme.name":                  userPath,
+		"theme.colors.primary":        repoPath,
+		"due.soonDays":                userPath,
+		"due.graceDays":               repoPath,
+		"keybindings.list.sort":       repoPath,
+		"keybindings.list.editStatus": userPath,
+	}
+	for setting, want := range origins {
+		if got := cfg.Origins[setting].Path; got != want {
+			t.Errorf("origin of %s: expected %s, got %s", setting, want, got)
+		}
+	}
+}
+
+func TestRepoConfigFromSubdirectory(t *testing.T) {
+	t.Setenv("BB_CONFIG", filepath.Join(t.TempDir(), "none.yml"))
+
+	repoDir := t.TempDir()
+	subDir := filepath.Join(repoDir, "src", "pkg")
+	if err := os.MkdirAll(filepath.Join(repoDir, ".beads"), 0755); err != nil {
+		t.Fatalf("failed to create .beads: %v", err)
+	}
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("failed to create subdirectory: %v", err)
+	}
+	repoPath := filepath.Join(repoDir, ".bb.yml")
+	if err := os.WriteFile(repoPath, []byte("theme: light\n"), 0644); err != nil {
+		t.Fatalf("failed to write repo config: %v", err)
+	}
+	t.Chdir(subDir)
+
+	if root := RepoRoot(); root != repoDir {
+		t.Errorf("expected repository root %s, got %s", repoDir, root)
+	}
+	if got := RepoConfigPath(); got != repoPath {
+		t.Errorf("expected repo config %s, got %q", repoPath, got)
+	}
+	if paths := WatchPaths(); !slices.Contains(paths, repoPath) {
+		t.Errorf("expected %s among watched paths %v", repoPath, paths)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Theme.Name != "light" {
+		t.Errorf("expected theme light from the repo config, got %q", cfg.Theme.Name)
+	}
+}
+
+func TestLoadProblems(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	configContent := `customCommands:
+  - key: o
+    description: Open
+    command: open {{.ID}}
+  - key: o
+    description: Other
+    command: other
+  - key: x
+    description: Bad context
+    command: x
+    context: board
+templates:
+  - name: Bug
+    priority: 7
+    colour: red
+due:
+  soonDays: many
+keybindings:
+  list:
+    sort: s
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("BB_CONFIG", configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	want := []struct {
+		line    int
+		message string
+	}{
+		{5, `custom command key "o" is already used in the list context at line 2`},
+		{11, `custom command "x" has unknown context "board"`},
+		{14, "priority must be 0-4, got 7"},
+		{15, `unknown field "colour"`},
+		{17, "cannot unmarshal"},
+	}
+	if len(cfg.Problems) != len(want) {
+		t.Fatalf("expected %d problems, got %d: %v", len(want), len(cfg.Problems), cfg.Problems)
+	}
+	for i, w := range want {
+		p := cfg.Problems[i]
+		if p.Path != configPath || p.Line != w.line || !strings.Contains(p.Message, w.message) {
+			t.Errorf("problem %d: expected %s:%d containing %q, got %v", i, configPath, w.line, w.message, p)
+		}
+	}
+
+	// Settings are still loaded; the later command with a duplicate key wins
+	if len(cfg.CustomCommands) != 2 || cfg.CustomCommands[0].Command != "other" {
+		t.Errorf("expected custom commands to load, got %v", cfg.CustomCommands)
+	}
+	if loc := cfg.Locate("keybindings.list.sort"); loc.Line != 20 {
+		t.Errorf("expected keybindings.list.sort at line 20, got %v", loc)
+	}
+}
+
+func TestCommandPrompts(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	configContent := `customCommands:
+  - key: B
+    description: Create branch
+    command: git checkout -b {{.ID}}-{{.Prompt.suffix}}
+    prompts:
+      - name: suffix
+      - name: kind
+        type: choice
+      - name: bad-name
+        type: checkbox
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("BB_CONFIG", configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	prompts := cfg.CustomCommands[0].Prompts
+	if len(prompts) != 3 {
+		t.Fatalf("expected 3 prompts, got %d", len(prompts))
+	}
+	// Type defaults to text and the title to the name
+	if prompts[0].Type != "text" || prompts[0].Title != "suffix" {
+		t.Errorf("expected text prompt titled 'suffix', got %+v", prompts[0])
+	}
+
+	want := []struct {
+		line    int
+		message string
+	}{
+		{7, `prompt "kind" is a choice without options`},
+		{9, `prompt name "bad-name"`},
+		{9, `prompt "bad-name" has unknown type "checkbox"`},
+	}
+	if len(cfg.Problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), cfg.Problems)
+	}
+	for i, w := range want {
+		p := cfg.Problems[i]
+		if p.Line != w.line || !strings.Contains(p.Message, w.message) {
+			t.Errorf("problem %d: expected line %d containing %q, got %v", i, w.line, w.message, p)
+		}
+	}
+}
+
+func TestLoadSyntaxError(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(configPath, []byte("customCommands:\n  - key: [o\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("BB_CONFIG", configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected syntax errors to be reported as problems, got %v", err)
+	}
+	if len(cfg.Problems) != 1 || cfg.Problems[0].Path != configPath || cfg.Problems[0].Line == 0 {
+		t.Fatalf("expected one located syntax problem, got %v", cfg.Problems)
+	}
 }
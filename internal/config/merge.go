@@ -0,0 +1,78 @@
+package config
+
+// merge layers a config read from path over c. Later layers win:
+//   - custom commands replace earlier commands bound to the same key
+//   - templates replace earlier templates with the same name
+//   - theme name, due thresholds, theme colors and keybindings are
+//     overridden one setting at a time
+//
+// Everything else from earlier layers is kept. Each setting taken from the
+// layer is recorded in c.Origins, and the layer's problems are collected.
+func (c *Config) merge(layer *Config, path string) {
+	c.Problems = append(c.Problems, layer.Problems...)
+
+	for _, cmd := range layer.CustomCommands {
+		kept := c.CustomCommands[:0]
+		for _, existing := range c.CustomCommands {
+			if existing.Key != cmd.Key {
+				kept = append(kept, existing)
+			}
+		}
+		c.CustomCommands = append(kept, cmd)
+		c.Origins["customCommands."+cmd.Key] = layer.at(path, "customCommands."+cmd.Key)
+	}
+
+	for _, tmpl := range layer.Templates {
+		replaced := false
+		for i := range c.Templates {
+			if c.Templates[i].Name == tmpl.Name {
+				c.Templates[i] = tmpl
+				replaced = true
+			}
+		}
+		if !replaced {
+			c.Templates = append(c.Templates, tmpl)
+		}
+		c.Origins["templates."+tmpl.Name] = layer.at(path, "templates."+tmpl.Name)
+	}
+
+	if layer.Due.SoonDays != nil {
+		c.Due.SoonDays = layer.Due.SoonDays
+		c.Origins["due.soonDays"] = layer.at(path, "due.soonDays")
+	}
+	if layer.Due.GraceDays != nil {
+		c.Due.GraceDays = layer.Due.GraceDays
+		c.Origins["due.graceDays"] = layer.at(path, "due.graceDays")
+	}
+
+	if layer.Theme.Name != "" {
+		c.Theme.Name = layer.Theme.Name
+		c.Origins["theme.name"] = layer.at(path, "theme.name")
+	}
+	for name, color := range layer.Theme.Colors {
+		if c.Theme.Colors == nil {
+			c.Theme.Colors = make(map[string]string)
+		}
+		c.Theme.Colors[name] = color
+		c.Origins["theme.colors."+name] = layer.at(path, "theme.colors."+name)
+	}
+
+	for context, bindings := range layer.Keybindings {
+		if c.Keybindings == nil {
+			c.Keybindings = make(map[string]map[string]KeyList)
+		}
+		if c.Keybindings[context] == nil {
+			c.Keybindings[context] = make(map[string]KeyList)
+		}
+		c.Origins["keybindings."+context] = layer.at(path, "keybindings."+context)
+		for name, keys := range bindings {
+			c.Keybindings[context][name] = keys
+			c.Origins["keybindings."+context+"."+name] = layer.at(path, "keybindings."+context+"."+name)
+		}
+	}
+}
+
+// at returns the location of a setting in the file the layer was read from
+func (c *Config) at(path, setting string) Location {
+	return Location{Path: path, Line: c.lines[setting]}
+}
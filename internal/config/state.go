@@ -0,0 +1,82 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Session is the UI state bb restores when it is started again in the same
+// repository. Enumerations are stored by name so reordering them does not
+// scramble saved state.
+type Session struct {
+	FocusedPanel   string   `json:"focusedPanel,omitempty"` // in-progress, open or closed
+	FilterQuery    string   `json:"filterQuery,omitempty"`
+	FilterMode     string   `json:"filterMode,omitempty"`
+	SortMode       string   `json:"sortMode,omitempty"`
+	CollapsedNodes []string `json:"collapsedNodes,omitempty"`
+	BoardColumn    int      `json:"boardColumn,omitempty"`
+	SelectedID     string   `json:"selectedId,omitempty"`
+}
+
+// StatePath returns the state file path. It checks in order:
+//  1. BB_STATE environment variable (direct path to state file)
+//  2. $XDG_STATE_HOME/bb/state.json
+//  3. ~/.local/state/bb/state.json
+func StatePath() string {
+	if stateFile := os.Getenv("BB_STATE"); stateFile != "" {
+		return stateFile
+	}
+	if xdgState := os.Getenv("XDG_STATE_HOME"); xdgState != "" {
+		return filepath.Join(xdgState, "bb", "state.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "state", "bb", "state.json")
+}
+
+// loadSessions reads the sessions of every repository, keyed by directory
+func loadSessions() (map[string]Session, error) {
+	sessions := make(map[string]Session)
+	data, err := os.ReadFile(StatePath())
+	if os.IsNotExist(err) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// LoadSession returns the saved session of the repository at dir. A missing
+// state file or repository yields an empty session.
+func LoadSession(dir string) (Session, error) {
+	sessions, err := loadSessions()
+	if err != nil {
+		return Session{}, err
+	}
+	return sessions[dir], nil
+}
+
+// SaveSession records the session of the repository at dir, keeping the
+// sessions of other repositories
+func SaveSession(dir string, session Session) error {
+	sessions, err := loadSessions()
+	if err != nil {
+		// An unreadable state file only held UI state; start over
+		sessions = make(map[string]Session)
+	}
+	sessions[dir] = session
+
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := StatePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	t.Setenv("BB_STATE", filepath.Join(t.TempDir(), "bb", "state.json"))
+
+	// Nothing saved yet
+	session, err := LoadSession("/repo/a")
+	if err != nil {
+		t.Fatalf("failed to load session: %v", err)
+	}
+	if session.FocusedPanel != "" || session.SelectedID != "" {
+		t.Errorf("expected empty session, got %+v", session)
+	}
+
+	a := Session{
+		FocusedPanel:   "closed",
+		FilterQuery:    "login",
+		FilterMode:     "Ready",
+		SortMode:       "Priority",
+		CollapsedNodes: []string{"bb-1", "bb-7"},
+		BoardColumn:    3,
+		SelectedID:     "bb-9",
+	}
+	if err := SaveSession("/repo/a", a); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+	if err := SaveSession("/repo/b", Session{FocusedPanel: "open"}); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+
+	got, err := LoadSession("/repo/a")
+	if err != nil {
+		t.Fatalf("failed to load session: %v", err)
+	}
+	if got.FocusedPanel != a.FocusedPanel || got.FilterQuery != a.FilterQuery ||
+		got.FilterMode != a.FilterMode || got.SortMode != a.SortMode ||
+		got.BoardColumn != a.BoardColumn || got.SelectedID != a.SelectedID ||
+		len(got.CollapsedNodes) != 2 || got.CollapsedNodes[1] != "bb-7" {
+		t.Errorf("expected %+v, got %+v", a, got)
+	}
+
+	got, _ = LoadSession("/repo/b")
+	if got.FocusedPanel != "open" {
+		t.Errorf("expected the other repository's session to be kept, got %+v", got)
+	}
+}
@@ -0,0 +1,377 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CustomCommandContexts are the valid contexts of a custom command
+var CustomCommandContexts = []string{"list", "detail", "global"}
+
+// CustomCommandModes are the valid ways of running a custom command
+var CustomCommandModes = []string{"background", "capture", "interactive", "patch"}
+
+// CustomCommandSelections are the valid sets of issues a custom command
+// applies to: the selected issue, each marked issue in turn, or all marked
+// issues at once
+var CustomCommandSelections = []string{"single", "each", "all"}
+
+// CommandPromptTypes are the valid types of a custom command prompt
+var CommandPromptTypes = []string{"text", "choice", "confirm", "issue"}
+
+// promptNamePattern matches prompt names usable as .Prompt.<name>
+var promptNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Location is a position in a config file; Line is 0 when unknown
+type Location struct {
+	Path string
+	Line int
+}
+
+// String formats the location as path:line
+func (l Location) String() string {
+	if l.Line == 0 {
+		return l.Path
+	}
+	return fmt.Sprintf("%s:%d", l.Path, l.Line)
+}
+
+// Problem is an invalid setting, located in the file it came from.
+// Problems are not fatal: the setting is ignored or falls back to its
+// default.
+type Problem struct {
+	Location
+	Message string
+	Fatal   bool // the file could not be parsed and was skipped entirely
+}
+
+// Error formats the problem as path:line: message
+func (p Problem) Error() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Location.String() + ": " + p.Message
+}
+
+// SortProblems orders problems by file, then line
+func SortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Path != problems[j].Path {
+			return problems[i].Path < problems[j].Path
+		}
+		return problems[i].Line < problems[j].Line
+	})
+}
+
+// Fatal returns the first problem that caused a whole file to be skipped,
+// or nil if every file was read
+func (c *Config) Fatal() *Problem {
+	for i := range c.Problems {
+		if c.Problems[i].Fatal {
+			return &c.Problems[i]
+		}
+	}
+	return nil
+}
+
+// Locate returns the location of a setting (named as in Origins)
+func (c *Config) Locate(setting string) Location {
+	return c.Origins[setting]
+}
+
+// yamlLinePattern matches the line prefix of yaml error messages
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// syntaxProblem turns a yaml parse error into a problem
+func syntaxProblem(path string, err error) Problem {
+	p := Problem{Location: Location{Path: path}, Message: err.Error(), Fatal: true}
+	if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		p.Line, _ = strconv.Atoi(m[1])
+		p.Message = m[2]
+	}
+	return p
+}
+
+// typeErrorProblems turns the messages of a yaml.TypeError into problems
+func typeErrorProblems(path string, err *yaml.TypeError) []Problem {
+	var problems []Problem
+	for _, msg := range err.Errors {
+		p := Problem{Location: Location{Path: path}, Message: msg}
+		if m := yamlLinePattern.FindStringSubmatch(msg); m != nil {
+			p.Line, _ = strconv.Atoi(m[1])
+			p.Message = m[2]
+		}
+		problems = append(problems, p)
+	}
+	return problems
+}
+
+// unknownFields reports mapping keys that match no field of the type the
+// node is decoded into. Shorthand scalar forms and type mismatches are
+// left to the decoder.
+func unknownFields(path string, node *yaml.Node, t reflect.Type) []Problem {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var problems []Problem
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		fields := make(map[string]reflect.Type)
+		var names []string
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			fields[name] = f.Type
+			names = append(names, name)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			ft, ok := fields[k.Value]
+			if !ok {
+				problems = append(problems, Problem{
+					Location: Location{Path: path, Line: k.Line},
+					Message:  fmt.Sprintf("unknown field %q (expected one of: %s)", k.Value, strings.Join(names, ", ")),
+				})
+				continue
+			}
+			problems = append(problems, unknownFields(path, v, ft)...)
+		}
+	case reflect.Slice:
+		if node.Kind == yaml.SequenceNode {
+			for _, item := range node.Content {
+				problems = append(problems, unknownFields(path, item, t.Elem())...)
+			}
+		}
+	case reflect.Map:
+		if node.Kind == yaml.MappingNode {
+			for i := 1; i < len(node.Content); i += 2 {
+				problems = append(problems, unknownFields(path, node.Content[i], t.Elem())...)
+			}
+		}
+	}
+	return problems
+}
+
+// mappingValue returns the value node of a key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// lineOf returns the line of a node, or 0 if it is missing
+func lineOf(node *yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+	return node.Line
+}
+
+// index records the line of each setting in a file, keyed as in Origins
+func index(doc *yaml.Node) map[string]int {
+	lines := make(map[string]int)
+	if cmds := mappingValue(doc, "customCommands"); cmds != nil && cmds.Kind == yaml.SequenceNode {
+		for _, item := range cmds.Content {
+			if k := mappingValue(item, "key"); k != nil {
+				lines["customCommands."+k.Value] = item.Line
+			}
+		}
+	}
+	if tmpls := mappingValue(doc, "templates"); tmpls != nil && tmpls.Kind == yaml.SequenceNode {
+		for _, item := range tmpls.Content {
+			name := mappingValue(item, "name")
+			if name == nil {
+				name = mappingValue(item, "type")
+			}
+			if name != nil {
+				lines["templates."+name.Value] = item.Line
+			} else {
+				lines["templates.task"] = item.Line
+			}
+		}
+	}
+	due := mappingValue(doc, "due")
+	for _, field := range []string{"soonDays", "graceDays"} {
+		if v := mappingValue(due, field); v != nil {
+			lines["due."+field] = v.Line
+		}
+	}
+	if theme := mappingValue(doc, "theme"); theme != nil {
+		if theme.Kind == yaml.ScalarNode {
+			lines["theme.name"] = theme.Line
+		} else if name := mappingValue(theme, "name"); name != nil {
+			lines["theme.name"] = name.Line
+		}
+		if colors := mappingValue(theme, "colors"); colors != nil && colors.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(colors.Content); i += 2 {
+				lines["theme.colors."+colors.Content[i].Value] = colors.Content[i].Line
+			}
+		}
+	}
+	if bindings := mappingValue(doc, "keybindings"); bindings != nil && bindings.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(bindings.Content); i += 2 {
+			context, remaps := bindings.Content[i], bindings.Content[i+1]
+			lines["keybindings."+context.Value] = context.Line
+			if remaps.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(remaps.Content); j += 2 {
+				lines["keybindings."+context.Value+"."+remaps.Content[j].Value] = remaps.Content[j].Line
+			}
+		}
+	}
+	return lines
+}
+
+// check reports invalid values in a single file: custom commands without a
+// key or command, with an unknown mode, selection or context or sharing a
+// key with another command; templates sharing a name or with an out-of-range priority; and
+// negative due thresholds
+func (c *Config) check(path string, doc *yaml.Node) []Problem {
+	var problems []Problem
+	report := func(line int, format string, args ...any) {
+		problems = append(problems, Problem{
+			Location: Location{Path: path, Line: line},
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	cmdNodes := mappingValue(doc, "customCommands")
+	taken := make(map[string]int) // context + key -> line
+	for i, cmd := range c.CustomCommands {
+		var node *yaml.Node
+		if cmdNodes != nil && i < len(cmdNodes.Content) {
+			node = cmdNodes.Content[i]
+		}
+		line := lineOf(node)
+		switch {
+		case cmd.Key == "":
+			report(line, "custom command %q has no key", cmd.Description)
+			continue
+		case strings.TrimSpace(cmd.Command) == "":
+			report(line, "custom command %q has no command", cmd.Key)
+		}
+		if cmd.Mode != "" && !contains(CustomCommandModes, cmd.Mode) {
+			report(lineOf(mappingValue(node, "mode")), "custom command %q has unknown mode %q (use %s)",
+				cmd.Key, cmd.Mode, strings.Join(CustomCommandModes, ", "))
+		}
+		if cmd.Selection != "" && !contains(CustomCommandSelections, cmd.Selection) {
+			report(lineOf(mappingValue(node, "selection")), "custom command %q has unknown selection %q (use %s)",
+				cmd.Key, cmd.Selection, strings.Join(CustomCommandSelections, ", "))
+		}
+		problems = append(problems, checkPrompts(path, cmd, mappingValue(node, "prompts"))...)
+		if cmd.Context != "" && !contains(CustomCommandContexts, cmd.Context) {
+			report(lineOf(mappingValue(node, "context")), "custom command %q has unknown context %q (use %s)",
+				cmd.Key, cmd.Context, strings.Join(CustomCommandContexts, ", "))
+			continue
+		}
+
+		contexts := []string{cmd.Context}
+		switch cmd.Context {
+		case "", "list":
+			contexts = []string{"list"}
+		case "global":
+			contexts = []string{"list", "detail"}
+		}
+		for _, context := range contexts {
+			slot := context + " " + cmd.Key
+			if first, dup := taken[slot]; dup {
+				report(line, "custom command key %q is already used in the %s context at line %d", cmd.Key, context, first)
+				break
+			}
+			taken[slot] = line
+		}
+	}
+
+	tmplNodes := mappingValue(doc, "templates")
+	names := make(map[string]int)
+	for i, tmpl := range c.Templates {
+		var node *yaml.Node
+		if tmplNodes != nil && i < len(tmplNodes.Content) {
+			node = tmplNodes.Content[i]
+		}
+		name := tmpl.Name
+		if name == "" {
+			name = tmpl.Type
+		}
+		if first, dup := names[name]; dup {
+			report(lineOf(node), "template name %q is already used at line %d", name, first)
+		} else {
+			names[name] = lineOf(node)
+		}
+		if tmpl.Priority != nil && (*tmpl.Priority < 0 || *tmpl.Priority > 4) {
+			report(lineOf(mappingValue(node, "priority")), "template %q: priority must be 0-4, got %d", name, *tmpl.Priority)
+		}
+	}
+
+	due := mappingValue(doc, "due")
+	if v := c.Due.SoonDays; v != nil && *v < 0 {
+		report(lineOf(mappingValue(due, "soonDays")), "due.soonDays must not be negative, got %d", *v)
+	}
+	if v := c.Due.GraceDays; v != nil && *v < 0 {
+		report(lineOf(mappingValue(due, "graceDays")), "due.graceDays must not be negative, got %d", *v)
+	}
+	return problems
+}
+
+// checkPrompts reports prompts of a custom command that have an unusable
+// or duplicate name, an unknown type, or a choice without options
+func checkPrompts(path string, cmd CustomCommand, nodes *yaml.Node) []Problem {
+	var problems []Problem
+	seen := make(map[string]bool)
+	for i, p := range cmd.Prompts {
+		var node *yaml.Node
+		if nodes != nil && i < len(nodes.Content) {
+			node = nodes.Content[i]
+		}
+		report := func(format string, args ...any) {
+			problems = append(problems, Problem{
+				Location: Location{Path: path, Line: lineOf(node)},
+				Message:  fmt.Sprintf("custom command %q: ", cmd.Key) + fmt.Sprintf(format, args...),
+			})
+		}
+		switch {
+		case !promptNamePattern.MatchString(p.Name):
+			report("prompt name %q must be a letter or underscore followed by letters, digits or underscores", p.Name)
+		case seen[p.Name]:
+			report("prompt %q is declared twice", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Type != "" && !contains(CommandPromptTypes, p.Type) {
+			report("prompt %q has unknown type %q (use %s)", p.Name, p.Type, strings.Join(CommandPromptTypes, ", "))
+		}
+		if p.Type == "choice" && len(p.Options) == 0 {
+			report("prompt %q is a choice without options", p.Name)
+		}
+	}
+	return problems
+}
+
+// contains reports whether list holds s
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format used for due and defer dates
+const DateLayout = "2006-01-02"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseDate parses an absolute or relative date, returning midnight of that
+// day in now's location. Accepted forms:
+//
+//	2026-01-15           absolute date
+//	today, tomorrow, yesterday
+//	+3d, +2w, +1m, +1y   offset from today (a leading - goes back)
+//	fri, next friday     the next such weekday after today
+func ParseDate(input string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch s {
+	case "":
+		return time.Time{}, fmt.Errorf("empty date")
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if t, err := time.ParseInLocation(DateLayout, s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	// Relative offset: +3d, -1w, +2m, +1y
+	if s[0] == '+' || s[0] == '-' {
+		if len(s) < 3 {
+			return time.Time{}, fmt.Errorf("invalid date offset %q", input)
+		}
+		n, err := strconv.Atoi(s[1 : len(s)-1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date offset %q", input)
+		}
+		if s[0] == '-' {
+			n = -n
+		}
+		switch s[len(s)-1] {
+		case 'd':
+			return today.AddDate(0, 0, n), nil
+		case 'w':
+			return today.AddDate(0, 0, 7*n), nil
+		case 'm':
+			return today.AddDate(0, n, 0), nil
+		case 'y':
+			return today.AddDate(n, 0, 0), nil
+		}
+		return time.Time{}, fmt.Errorf("invalid date unit in %q (use d, w, m or y)", input)
+	}
+
+	// Weekday: "fri", "next fri"
+	if day, ok := weekdays[strings.TrimPrefix(s, "next ")]; ok {
+		days := (int(day) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q", input)
+}
+
+// IsDeferred reports whether an open task is deferred to a day after now's
+// date. Tasks deferred until today are active again.
+func (t Task) IsDeferred(now time.Time) bool {
+	if t.Status == "closed" || t.DeferUntil == nil {
+		return false
+	}
+	wake := t.DeferUntil.Format(DateLayout)
+	return wake > now.Format(DateLayout)
+}
+
+// DueStatus classifies an open task by how close its due date is
+type DueStatus int
+
+const (
+	DueNone    DueStatus = iota // closed, or no due date
+	DueLater                    // due after the due-soon window
+	DueSoon                     // due within the window (or overdue within the grace period)
+	DueOverdue                  // past its due date by more than the grace period
+)
+
+// DueStatus reports whether the task is overdue or due within soonDays of
+// now's date. A task only counts as overdue graceDays after its due date.
+func (t Task) DueStatus(now time.Time, soonDays, graceDays int) DueStatus {
+	if t.Status == "closed" || t.DueDate == nil {
+		return DueNone
+	}
+	days := t.DaysUntilDue(now)
+	switch {
+	case days < -graceDays:
+		return DueOverdue
+	case days <= soonDays:
+		return DueSoon
+	default:
+		return DueLater
+	}
+}
+
+// DaysUntilDue returns the number of days from now's date to the due date,
+// negative once it has passed. Tasks without a due date return 0.
+func (t Task) DaysUntilDue(now time.Time) int {
+	if t.DueDate == nil {
+		return 0
+	}
+	y, m, d := t.DueDate.Date()
+	due := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(due.Sub(today).Hours()) / 24
+}
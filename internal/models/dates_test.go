@@ -0,0 +1,108 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2026-12-01", "2026-12-01"},
+		{"today", "2026-10-14"},
+		{"Tomorrow", "2026-10-15"},
+		{"yesterday", "2026-10-13"},
+		{"+3d", "2026-10-17"},
+		{"-1d", "2026-10-13"},
+		{"+2w", "2026-10-28"},
+		{"+1m", "2026-11-14"},
+		{"+1y", "2027-10-14"},
+		{"fri", "2026-10-16"},
+		{"next fri", "2026-10-16"},
+		{"friday", "2026-10-16"},
+		{"wed", "2026-10-21"}, // same weekday means next week
+		{"next monday", "2026-10-19"},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseDate(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got.Format(DateLayout) != tt.want {
+			t.Errorf("ParseDate(%q) = %s, want %s", tt.input, got.Format(DateLayout), tt.want)
+		}
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	now := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	for _, input := range []string{"", "+", "+3", "+3x", "soon", "next week", "2026-13-01"} {
+		if _, err := ParseDate(input, now); err == nil {
+			t.Errorf("ParseDate(%q) expected error", input)
+		}
+	}
+}
+
+func TestIsDeferred(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	day := func(s string) *time.Time {
+		d, _ := time.Parse(DateLayout, s)
+		return &d
+	}
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{"no defer date", Task{Status: "open"}, false},
+		{"deferred until tomorrow", Task{Status: "open", DeferUntil: day("2026-10-15")}, true},
+		{"wakes today", Task{Status: "open", DeferUntil: day("2026-10-14")}, false},
+		{"defer date passed", Task{Status: "open", DeferUntil: day("2026-10-01")}, false},
+		{"closed", Task{Status: "closed", DeferUntil: day("2026-10-15")}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.task.IsDeferred(now); got != tt.want {
+			t.Errorf("%s: IsDeferred() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDueStatus(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	day := func(s string) *time.Time {
+		d, _ := time.Parse(DateLayout, s)
+		return &d
+	}
+
+	tests := []struct {
+		name  string
+		task  Task
+		grace int
+		want  DueStatus
+		days  int
+	}{
+		{"no due date", Task{Status: "open"}, 0, DueNone, 0},
+		{"closed", Task{Status: "closed", DueDate: day("2026-10-01")}, 0, DueNone, -13},
+		{"due today", Task{Status: "open", DueDate: day("2026-10-14")}, 0, DueSoon, 0},
+		{"due in 3 days", Task{Status: "open", DueDate: day("2026-10-17")}, 0, DueSoon, 3},
+		{"due in 4 days", Task{Status: "open", DueDate: day("2026-10-18")}, 0, DueLater, 4},
+		{"a day late", Task{Status: "in_progress", DueDate: day("2026-10-13")}, 0, DueOverdue, -1},
+		{"a day late within grace", Task{Status: "open", DueDate: day("2026-10-13")}, 1, DueSoon, -1},
+		{"two days late past grace", Task{Status: "open", DueDate: day("2026-10-12")}, 1, DueOverdue, -2},
+	}
+	for _, tt := range tests {
+		if got := tt.task.DueStatus(now, 3, tt.grace); got != tt.want {
+			t.Errorf("%s: DueStatus() = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.task.DaysUntilDue(now); got != tt.days {
+			t.Errorf("%s: DaysUntilDue() = %d, want %d", tt.name, got, tt.days)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package models
+
+import "sort"
+
+// IsBlocking returns true if this dependency is a blocking relationship.
+// bd uses "blocks" as the default type; an empty type is treated the same.
+func (d Dependency) IsBlocking() bool {
+	return d.Type == "" || d.Type == DepBlocks
+}
+
+// DepGraph indexes the blocking relationships between issues. Edges are
+// collected from Dependencies as well as the BlockedBy/Blocks lists, so it
+// works with whichever form bd returned.
+type DepGraph struct {
+	blockers   map[string][]string // issue → issues blocking it
+	dependents map[string][]string // issue → issues it blocks
+}
+
+// NewDepGraph builds a dependency graph from a task list
+func NewDepGraph(tasks []Task) *DepGraph {
+	g := &DepGraph{
+		blockers:   make(map[string][]string),
+		dependents: make(map[string][]string),
+	}
+	seen := make(map[[2]string]bool)
+	addEdge := func(blocker, blocked string) {
+		if blocker == "" || blocked == "" || blocker == blocked {
+			return
+		}
+		edge := [2]string{blocker, blocked}
+		if seen[edge] {
+			return
+		}
+		seen[edge] = true
+		g.blockers[blocked] = append(g.blockers[blocked], blocker)
+		g.dependents[blocker] = append(g.dependents[blocker], blocked)
+	}
+	for _, t := range tasks {
+		for _, dep := range t.Dependencies {
+			if dep.IsBlocking() {
+				addEdge(dep.DependsOnID, t.ID)
+			}
+		}
+		for _, id := range t.BlockedBy {
+			addEdge(id, t.ID)
+		}
+		for _, id := range t.Blocks {
+			addEdge(t.ID, id)
+		}
+	}
+	for _, ids := range g.blockers {
+		sort.Strings(ids)
+	}
+	for _, ids := range g.dependents {
+		sort.Strings(ids)
+	}
+	return g
+}
+
+// Blockers returns the IDs of issues blocking id, sorted
+func (g *DepGraph) Blockers(id string) []string {
+	return g.blockers[id]
+}
+
+// Dependents returns the IDs of issues blocked by id, sorted
+func (g *DepGraph) Dependents(id string) []string {
+	return g.dependents[id]
+}
+
+// Levels walks the graph outward from id up to depth steps in each
+// direction. upstream[0] holds the direct blockers, upstream[1] their
+// blockers and so on; downstream is the same for dependents. Each issue
+// appears at most once, at the level where it is first reached.
+func (g *DepGraph) Levels(id string, depth int) (upstream, downstream [][]string) {
+	walk := func(next func(string) []string) [][]string {
+		var levels [][]string
+		placed := map[string]bool{id: true}
+		frontier := []string{id}
+		for d := 0; d < depth && len(frontier) > 0; d++ {
+			var level []string
+			for _, current := range frontier {
+				for _, n := range next(current) {
+					if !placed[n] {
+						placed[n] = true
+						level = append(level, n)
+					}
+				}
+			}
+			if len(level) == 0 {
+				break
+			}
+			sort.Strings(level)
+			levels = append(levels, level)
+			frontier = level
+		}
+		return levels
+	}
+	return walk(g.Blockers), walk(g.Dependents)
+}
+
+// BlockerChain returns the longest chain of unresolved blockers that ends at
+// one of targets, ordered from the issue that has to be done first to the
+// target. Blockers for which resolved returns true are skipped. Cycles are
+// cut where they are found, so the result is always finite.
+func (g *DepGraph) BlockerChain(targets []string, resolved func(string) bool) []string {
+	memo := make(map[string][]string)
+	onPath := make(map[string]bool)
+	var longest func(id string) []string
+	longest = func(id string) []string {
+		if chain, ok := memo[id]; ok {
+			return chain
+		}
+		onPath[id] = true
+		var best []string
+		for _, b := range g.Blockers(id) {
+			if onPath[b] || resolved(b) {
+				continue
+			}
+			if chain := longest(b); len(chain) > len(best) {
+				best = chain
+			}
+		}
+		onPath[id] = false
+		chain := append(append([]string(nil), best...), id)
+		memo[id] = chain
+		return chain
+	}
+
+	var best []string
+	for _, id := range targets {
+		if chain := longest(id); len(chain) > len(best) {
+			best = chain
+		}
+	}
+	return best
+}
+
+// Unblocks returns how many unresolved issues are waiting on id, directly
+// or through a chain of other unresolved issues
+func (g *DepGraph) Unblocks(id string, resolved func(string) bool) int {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	count := 0
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, d := range g.Dependents(current) {
+			if seen[d] || resolved(d) {
+				continue
+			}
+			seen[d] = true
+			count++
+			queue = append(queue, d)
+		}
+	}
+	return count
+}
+
+// Cycles returns each group of issues that block each other in a cycle,
+// with members sorted by ID and groups sorted by their first member
+func (g *DepGraph) Cycles() [][]string {
+	// Tarjan's strongly connected components
+	var nodes []string
+	seenNode := make(map[string]bool)
+	for _, m := range []map[string][]string{g.blockers, g.dependents} {
+		for id := range m {
+			if !seenNode[id] {
+				seenNode[id] = true
+				nodes = append(nodes, id)
+			}
+		}
+	}
+	sort.Strings(nodes)
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	next := 0
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = next
+		lowlink[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, d := range g.Dependents(id) {
+			if _, visited := index[d]; !visited {
+				visit(d)
+				lowlink[id] = min(lowlink[id], lowlink[d])
+			} else if onStack[d] {
+				lowlink[id] = min(lowlink[id], index[d])
+			}
+		}
+
+		if lowlink[id] == index[id] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == id {
+					break
+				}
+			}
+			if len(component) > 1 {
+				sort.Strings(component)
+				cycles = append(cycles, component)
+			}
+		}
+	}
+
+	for _, id := range nodes {
+		if _, visited := index[id]; !visited {
+			visit(id)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+// Path returns the shortest chain of blocking edges from one issue to
+// another (from blocks …, which blocks to), or nil if to is not reachable
+func (g *DepGraph) Path(from, to string) []string {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var path []string
+			for id := to; id != ""; id = prev[id] {
+				path = append([]string{id}, path...)
+			}
+			return path
+		}
+		for _, d := range g.Dependents(current) {
+			if _, seen := prev[d]; !seen {
+				prev[d] = current
+				queue = append(queue, d)
+			}
+		}
+	}
+	return nil
+}
+
+// WouldCycle reports whether making blocker block blocked would close a
+// cycle, returning the existing chain from blocked back to blocker
+func (g *DepGraph) WouldCycle(blocker, blocked string) ([]string, bool) {
+	if blocker == blocked {
+		return []string{blocker}, true
+	}
+	path := g.Path(blocked, blocker)
+	return path, path != nil
+}
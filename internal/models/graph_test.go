@@ -0,0 +1,130 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDepGraphEdges(t *testing.T) {
+	tasks := []Task{
+		{ID: "a"},
+		{ID: "b", Dependencies: []Dependency{{IssueID: "b", DependsOnID: "a", Type: "blocks"}}},
+		{ID: "c", BlockedBy: []string{"b"}},
+		{ID: "d", Blocks: []string{"c"}},
+		{ID: "e", Dependencies: []Dependency{{IssueID: "e", DependsOnID: "a", Type: "parent-child"}}},
+	}
+	g := NewDepGraph(tasks)
+
+	if got := g.Blockers("c"); !reflect.DeepEqual(got, []string{"b", "d"}) {
+		t.Errorf("Blockers(c) = %v, want [b d]", got)
+	}
+	if got := g.Dependents("a"); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Dependents(a) = %v, want [b] (parent-child is not blocking)", got)
+	}
+	if got := g.Blockers("a"); len(got) != 0 {
+		t.Errorf("Blockers(a) = %v, want none", got)
+	}
+}
+
+func TestDepGraphLevels(t *testing.T) {
+	// a → b → c → d, and a → c directly
+	tasks := []Task{
+		{ID: "a"},
+		{ID: "b", BlockedBy: []string{"a"}},
+		{ID: "c", BlockedBy: []string{"a", "b"}},
+		{ID: "d", BlockedBy: []string{"c"}},
+	}
+	g := NewDepGraph(tasks)
+
+	up, down := g.Levels("c", 5)
+	if want := [][]string{{"a", "b"}}; !reflect.DeepEqual(up, want) {
+		t.Errorf("upstream = %v, want %v", up, want)
+	}
+	if want := [][]string{{"d"}}; !reflect.DeepEqual(down, want) {
+		t.Errorf("downstream = %v, want %v", down, want)
+	}
+
+	_, down = g.Levels("a", 1)
+	if want := [][]string{{"b", "c"}}; !reflect.DeepEqual(down, want) {
+		t.Errorf("downstream depth 1 = %v, want %v", down, want)
+	}
+}
+
+func TestDepGraphBlockerChain(t *testing.T) {
+	// a → b → d, c → d, e → a (e is closed)
+	tasks := []Task{
+		{ID: "a", BlockedBy: []string{"e"}},
+		{ID: "b", BlockedBy: []string{"a"}},
+		{ID: "c"},
+		{ID: "d", BlockedBy: []string{"b", "c"}},
+		{ID: "e", Status: "closed"},
+	}
+	g := NewDepGraph(tasks)
+	closed := map[string]bool{"e": true}
+	resolved := func(id string) bool { return closed[id] }
+
+	if got, want := g.BlockerChain([]string{"d"}, resolved), []string{"a", "b", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BlockerChain(d) = %v, want %v", got, want)
+	}
+	if got, want := g.BlockerChain([]string{"c"}, resolved), []string{"c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BlockerChain(c) = %v, want %v", got, want)
+	}
+
+	if got := g.Unblocks("a", resolved); got != 2 {
+		t.Errorf("Unblocks(a) = %d, want 2", got)
+	}
+	if got := g.Unblocks("d", resolved); got != 0 {
+		t.Errorf("Unblocks(d) = %d, want 0", got)
+	}
+}
+
+func TestDepGraphCycles(t *testing.T) {
+	// a → b → c → a is a cycle; d hangs off it; e ⇄ f is another
+	tasks := []Task{
+		{ID: "a", BlockedBy: []string{"c"}},
+		{ID: "b", BlockedBy: []string{"a"}},
+		{ID: "c", BlockedBy: []string{"b"}},
+		{ID: "d", BlockedBy: []string{"c"}},
+		{ID: "e", BlockedBy: []string{"f"}},
+		{ID: "f", BlockedBy: []string{"e"}},
+	}
+	g := NewDepGraph(tasks)
+
+	want := [][]string{{"a", "b", "c"}, {"e", "f"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+
+	// Chains stay finite in the presence of cycles
+	never := func(string) bool { return false }
+	if got := g.BlockerChain([]string{"d"}, never); len(got) != 4 {
+		t.Errorf("BlockerChain(d) = %v, want 4 issues", got)
+	}
+	if got := g.Unblocks("a", never); got != 3 {
+		t.Errorf("Unblocks(a) = %d, want 3", got)
+	}
+}
+
+func TestDepGraphWouldCycle(t *testing.T) {
+	// a → b → c
+	tasks := []Task{
+		{ID: "a"},
+		{ID: "b", BlockedBy: []string{"a"}},
+		{ID: "c", BlockedBy: []string{"b"}},
+	}
+	g := NewDepGraph(tasks)
+
+	path, cycle := g.WouldCycle("c", "a")
+	if !cycle {
+		t.Fatal("c blocking a should close a cycle")
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(path, want) {
+		t.Errorf("cycle path = %v, want %v", path, want)
+	}
+	if _, cycle := g.WouldCycle("a", "c"); cycle {
+		t.Error("a blocking c should not close a cycle")
+	}
+	if _, cycle := g.WouldCycle("a", "a"); !cycle {
+		t.Error("an issue blocking itself is a cycle")
+	}
+}
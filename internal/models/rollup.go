@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Rollup summarises the descendants of an issue
+type Rollup struct {
+	Total    int        // number of descendants
+	Done     int        // closed descendants
+	Priority int        // most urgent priority among open descendants, -1 if none
+	Due      *time.Time // earliest due date among open descendants
+}
+
+// Percent returns the share of closed descendants, 0-100
+func (r Rollup) Percent() int {
+	if r.Total == 0 {
+		return 0
+	}
+	return r.Done * 100 / r.Total
+}
+
+// ComputeRollups returns the rollup of every issue that has children,
+// counting descendants at every depth. Parents are resolved with
+// GetParentID; a malformed hierarchy that loops back on itself is only
+// counted once.
+func ComputeRollups(tasks []Task) map[string]Rollup {
+	byID := make(map[string]*Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+	children := make(map[string][]*Task)
+	for i := range tasks {
+		parentID := tasks[i].GetParentID()
+		if _, ok := byID[parentID]; ok && parentID != tasks[i].ID {
+			children[parentID] = append(children[parentID], &tasks[i])
+		}
+	}
+
+	rollups := make(map[string]Rollup)
+	for id := range children {
+		r := Rollup{Priority: -1}
+		seen := map[string]bool{id: true}
+		stack := append([]*Task(nil), children[id]...)
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen[t.ID] {
+				continue
+			}
+			seen[t.ID] = true
+			r.Total++
+			if t.Status == "closed" {
+				r.Done++
+			} else {
+				if r.Priority < 0 || t.Priority < r.Priority {
+					r.Priority = t.Priority
+				}
+				if t.DueDate != nil && (r.Due == nil || t.DueDate.Before(*r.Due)) {
+					r.Due = t.DueDate
+				}
+			}
+			stack = append(stack, children[t.ID]...)
+		}
+		rollups[id] = r
+	}
+	return rollups
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeRollups(t *testing.T) {
+	due1 := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	due2 := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	due3 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []Task{
+		{ID: "ep", Priority: 3},
+		{ID: "ep.1", Status: "closed", Priority: 0, DueDate: &due3},
+		{ID: "ep.2", Status: "open", Priority: 2, DueDate: &due1},
+		{ID: "ep.2.1", Status: "in_progress", Priority: 1, DueDate: &due2},
+		{ID: "other", Status: "open", Priority: 2, Dependencies: []Dependency{{IssueID: "other", DependsOnID: "ep", Type: "parent-child"}}},
+	}
+	rollups := ComputeRollups(tasks)
+
+	ep, ok := rollups["ep"]
+	if !ok {
+		t.Fatal("expected rollup for ep")
+	}
+	if ep.Total != 4 || ep.Done != 1 {
+		t.Errorf("ep progress = %d/%d, want 1/4", ep.Done, ep.Total)
+	}
+	if ep.Percent() != 25 {
+		t.Errorf("ep percent = %d, want 25", ep.Percent())
+	}
+	// Closed descendants don't count towards priority or due date
+	if ep.Priority != 1 {
+		t.Errorf("ep priority = %d, want 1", ep.Priority)
+	}
+	if ep.Due == nil || !ep.Due.Equal(due2) {
+		t.Errorf("ep due = %v, want %v", ep.Due, due2)
+	}
+
+	if r := rollups["ep.2"]; r.Total != 1 || r.Done != 0 {
+		t.Errorf("ep.2 progress = %d/%d, want 0/1", r.Done, r.Total)
+	}
+	if _, ok := rollups["ep.2.1"]; ok {
+		t.Error("leaf issues should have no rollup")
+	}
+}
+
+func TestComputeRollupsAllClosed(t *testing.T) {
+	tasks := []Task{
+		{ID: "ep"},
+		{ID: "ep.1", Status: "closed"},
+	}
+	r := ComputeRollups(tasks)["ep"]
+	if r.Percent() != 100 || r.Priority != -1 || r.Due != nil {
+		t.Errorf("rollup = %+v, want 100%% with no open priority or due date", r)
+	}
+}
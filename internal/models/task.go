@@ -6,6 +6,14 @@ import (
 	"time"
 )
 
+// Dependency types understood by bd
+const (
+	DepBlocks         = "blocks"
+	DepParentChild    = "parent-child"
+	DepRelated        = "related"
+	DepDiscoveredFrom = "discovered-from"
+)
+
 // Dependency represents a relationship between two issues (from bd list JSON)
 type Dependency struct {
 	IssueID     string `json:"issue_id"`
@@ -118,9 +118,9 @@ func (b InlineBar) View(width int) string {
 	var content strings.Builder
 
 	// Colors for light background bar (vim/tmux inspired)
-	barBg := lipgloss.Color("7")    // White/light background
-	darkText := lipgloss.Color("0") // Black text
-	accentText := lipgloss.Color("4") // Blue for accents
+	barBg := ColorBarBackground  // White/light background
+	darkText := ColorBarText     // Black text
+	accentText := ColorBarAccent // Blue for accents
 
 	// Title and subtitle
 	titleStyle := lipgloss.NewStyle().
@@ -1,6 +1,12 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines all keybindings
 type KeyMap struct {
@@ -13,10 +19,11 @@ type KeyMap struct {
 	PageDown key.Binding
 
 	// Actions
-	Select  key.Binding
-	Add     key.Binding
-	Delete  key.Binding
-	Refresh key.Binding
+	Select   key.Binding
+	Add      key.Binding
+	AddChild key.Binding
+	Delete   key.Binding
+	Refresh  key.Binding
 
 	// Field-specific editing
 	EditTitle       key.Binding
@@ -25,12 +32,21 @@ type KeyMap struct {
 	EditType        key.Binding
 	EditDescription key.Binding
 	EditNotes       key.Binding
+	EditLabels      key.Binding
+	EditAssignee    key.Binding
+	EditDueDate     key.Binding
+	EditDeferDate   key.Binding
 	AddComment      key.Binding
 	CopyID          key.Binding
 
 	// Dependency management
 	AddBlocker    key.Binding
 	RemoveBlocker key.Binding
+	MoveParent    key.Binding
+
+	// Multi-selection
+	ToggleMark key.Binding
+	ClearMarks key.Binding
 
 	// Filtering
 	Filter     key.Binding
@@ -38,6 +54,8 @@ type KeyMap struct {
 	Ready      key.Binding
 	Open       key.Binding
 	Closed     key.Binding
+	Deferred   key.Binding
+	Due        key.Binding
 	All        key.Binding
 
 	// Sorting
@@ -46,8 +64,16 @@ type KeyMap struct {
 	// Tree
 	ToggleExpand key.Binding
 
+	// Detail view history
+	HistoryBack    key.Binding
+	HistoryForward key.Binding
+
 	// Views
-	Board key.Binding
+	Board    key.Binding
+	Graph    key.Binding
+	Report   key.Binding
+	Timeline key.Binding
+	Calendar key.Binding
 
 	// UI
 	Help      key.Binding
@@ -101,6 +127,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a", "c"),
 			key.WithHelp("a/c", "add"),
 		),
+		AddChild: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "add child"),
+		),
 		Delete: key.NewBinding(
 			key.WithKeys("x"),
 			key.WithHelp("x", "delete"),
@@ -139,6 +169,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("n"),
 			key.WithHelp("n", "edit notes"),
 		),
+		EditLabels: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "edit labels"),
+		),
+		EditAssignee: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "assign"),
+		),
+		EditDueDate: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "due date"),
+		),
+		EditDeferDate: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "snooze"),
+		),
 		AddComment: key.NewBinding(
 			key.WithKeys("C"),
 			key.WithHelp("C", "add comment"),
@@ -147,11 +193,25 @@ func DefaultKeyMap() KeyMap {
 		// Dependency management
 		AddBlocker: key.NewBinding(
 			key.WithKeys("B"),
-			key.WithHelp("B", "add blocker"),
+			key.WithHelp("B", "add dependency"),
 		),
 		RemoveBlocker: key.NewBinding(
 			key.WithKeys("D"),
-			key.WithHelp("D", "remove blocker"),
+			key.WithHelp("D", "remove dependency"),
+		),
+		MoveParent: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move under…"),
+		),
+
+		// Multi-selection
+		ToggleMark: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "mark/unmark"),
+		),
+		ClearMarks: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "clear marks"),
 		),
 
 		// Filtering
@@ -175,6 +235,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("O"),
 			key.WithHelp("O", "closed"),
 		),
+		Deferred: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "deferred"),
+		),
+		Due: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "overdue/due soon"),
+		),
 		All: key.NewBinding(
 			key.WithKeys("A"),
 			key.WithHelp("A", "all"),
@@ -192,11 +260,37 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("space", "expand/collapse"),
 		),
 
+		// Detail view history
+		HistoryBack: key.NewBinding(
+			key.WithKeys("[", "backspace"),
+			key.WithHelp("[", "back"),
+		),
+		HistoryForward: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "forward"),
+		),
+
 		// Views
 		Board: key.NewBinding(
 			key.WithKeys("b"),
 			key.WithHelp("b", "board view"),
 		),
+		Graph: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "dependency graph"),
+		),
+		Report: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "blocker analysis"),
+		),
+		Timeline: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "timeline"),
+		),
+		Calendar: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "calendar"),
+		),
 
 		// UI
 		Help: key.NewBinding(
@@ -253,11 +347,13 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	groups := [][]key.Binding{
 		{k.Up, k.Down, k.Top, k.Bottom, k.PageUp, k.PageDown},
-		{k.Select, k.Add, k.Delete, k.Refresh},
+		{k.Select, k.Add, k.AddChild, k.Delete, k.Refresh},
 		{k.EditTitle, k.EditStatus, k.EditPriority, k.EditType, k.EditDescription, k.EditNotes},
-		{k.AddComment, k.CopyID, k.AddBlocker, k.RemoveBlocker},
-		{k.Filter, k.Ready, k.Open, k.Closed, k.All, k.Sort},
-		{k.Board, k.Help, k.Quit, k.Cancel},
+		{k.EditLabels, k.EditAssignee, k.EditDueDate, k.EditDeferDate},
+		{k.AddComment, k.CopyID, k.AddBlocker, k.RemoveBlocker, k.MoveParent},
+		{k.ToggleMark, k.ClearMarks},
+		{k.Filter, k.Ready, k.Open, k.Closed, k.Deferred, k.Due, k.All, k.Sort},
+		{k.Board, k.Graph, k.Report, k.Timeline, k.Calendar, k.Help, k.Quit, k.Cancel},
 	}
 	// Add custom commands as a separate group if present
 	if len(k.CustomCommands) > 0 {
@@ -265,3 +361,346 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	}
 	return groups
 }
+
+// Keybinding contexts. Each has its own key map, so a key can be remapped
+// in one place without affecting the others.
+const (
+	ContextList   = "list"
+	ContextDetail = "detail"
+	ContextBoard  = "board"
+	ContextForm   = "form"
+	ContextModal  = "modal"
+)
+
+// KeyContexts lists the keybinding contexts in display order
+var KeyContexts = []string{ContextList, ContextDetail, ContextBoard, ContextForm, ContextModal}
+
+// contextBindings lists the bindings each context acts on. Only these can
+// be remapped there, and only these are checked for conflicts. The zoom,
+// pan and jump keys of the graph, timeline and calendar views (+ = - . f w
+// t) are fixed: they share the list context, where most of them are taken.
+var contextBindings = map[string][]string{
+	ContextList: {
+		"up", "down", "top", "bottom", "pageUp", "pageDown",
+		"select", "add", "addChild", "delete", "refresh",
+		"editTitle", "editStatus", "editPriority", "editType", "editDescription", "editNotes",
+		"editLabels", "editAssignee", "editDueDate", "editDeferDate", "addComment", "copyID",
+		"addBlocker", "removeBlocker", "moveParent", "toggleMark", "clearMarks",
+		"filter", "ready", "open", "closed", "deferred", "due", "all", "sort", "toggleExpand",
+		"historyBack", "historyForward",
+		"board", "graph", "report", "timeline", "calendar",
+		"help", "quit", "cancel", "tab", "shiftTab", "prevView", "nextView",
+	},
+	ContextDetail: {
+		"select", "tab", "shiftTab", "historyBack", "historyForward",
+		"graph", "report", "help", "cancel",
+	},
+	ContextBoard: {
+		"up", "down", "top", "bottom", "prevView", "nextView",
+		"select", "board", "help", "quit", "cancel",
+	},
+	ContextForm:  {"submit", "cancel", "tab", "shiftTab"},
+	ContextModal: {"up", "down", "select", "cancel"},
+}
+
+// helpPairs are bindings whose help entry also describes the binding that
+// follows it (j/k, g/G, ...)
+var helpPairs = [][2]string{
+	{"up", "down"},
+	{"top", "bottom"},
+	{"pageUp", "pageDown"},
+	{"prevView", "nextView"},
+}
+
+// KeyMaps holds the effective key map of each context
+type KeyMaps struct {
+	List   KeyMap // panels, plus the graph, report, timeline, calendar and help views
+	Detail KeyMap // issue details
+	Board  KeyMap // board view
+	Form   KeyMap // create form and text editors
+	Modal  KeyMap // selection modals
+}
+
+// DefaultKeyMaps returns the default key map for every context
+func DefaultKeyMaps() KeyMaps {
+	form := DefaultKeyMap()
+	// Enter submits single-line fields and the create form; multi-line
+	// editors leave it to the textarea
+	form.Submit = key.NewBinding(
+		key.WithKeys("enter", "ctrl+s"),
+		key.WithHelp("enter/^s", "submit"),
+	)
+	return KeyMaps{
+		List:   DefaultKeyMap(),
+		Detail: DefaultKeyMap(),
+		Board:  DefaultKeyMap(),
+		Form:   form,
+		Modal:  DefaultKeyMap(),
+	}
+}
+
+// Context returns the key map of the named context, or nil if there is none
+func (k *KeyMaps) Context(name string) *KeyMap {
+	switch name {
+	case ContextList:
+		return &k.List
+	case ContextDetail:
+		return &k.Detail
+	case ContextBoard:
+		return &k.Board
+	case ContextForm:
+		return &k.Form
+	case ContextModal:
+		return &k.Modal
+	}
+	return nil
+}
+
+// bindings maps the config names of the bindings to the key map's fields
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":              &k.Up,
+		"down":            &k.Down,
+		"top":             &k.Top,
+		"bottom":          &k.Bottom,
+		"pageUp":          &k.PageUp,
+		"pageDown":        &k.PageDown,
+		"select":          &k.Select,
+		"add":             &k.Add,
+		"addChild":        &k.AddChild,
+		"delete":          &k.Delete,
+		"refresh":         &k.Refresh,
+		"editTitle":       &k.EditTitle,
+		"editStatus":      &k.EditStatus,
+		"editPriority":    &k.EditPriority,
+		"editType":        &k.EditType,
+		"editDescription": &k.EditDescription,
+		"editNotes":       &k.EditNotes,
+		"editLabels":      &k.EditLabels,
+		"editAssignee":    &k.EditAssignee,
+		"editDueDate":     &k.EditDueDate,
+		"editDeferDate":   &k.EditDeferDate,
+		"addComment":      &k.AddComment,
+		"copyID":          &k.CopyID,
+		"addBlocker":      &k.AddBlocker,
+		"removeBlocker":   &k.RemoveBlocker,
+		"moveParent":      &k.MoveParent,
+		"toggleMark":      &k.ToggleMark,
+		"clearMarks":      &k.ClearMarks,
+		"filter":          &k.Filter,
+		"ready":           &k.Ready,
+		"open":            &k.Open,
+		"closed":          &k.Closed,
+		"deferred":        &k.Deferred,
+		"due":             &k.Due,
+		"all":             &k.All,
+		"sort":            &k.Sort,
+		"toggleExpand":    &k.ToggleExpand,
+		"historyBack":     &k.HistoryBack,
+		"historyForward":  &k.HistoryForward,
+		"board":           &k.Board,
+		"graph":           &k.Graph,
+		"report":          &k.Report,
+		"timeline":        &k.Timeline,
+		"calendar":        &k.Calendar,
+		"help":            &k.Help,
+		"quit":            &k.Quit,
+		"cancel":          &k.Cancel,
+		"submit":          &k.Submit,
+		"tab":             &k.Tab,
+		"shiftTab":        &k.ShiftTab,
+		"prevView":        &k.PrevView,
+		"nextView":        &k.NextView,
+	}
+}
+
+// ContextBindings returns the names of the bindings a context acts on
+func ContextBindings(context string) []string {
+	return contextBindings[context]
+}
+
+// BindingFor returns the name of the binding a key triggers in a context,
+// or "" if it is not bound there
+func (k *KeyMaps) BindingFor(context, keyStr string) string {
+	km := k.Context(context)
+	if km == nil {
+		return ""
+	}
+	bindings := km.bindings()
+	for _, name := range contextBindings[context] {
+		b := bindings[name]
+		if !b.Enabled() {
+			continue
+		}
+		for _, bk := range b.Keys() {
+			if bk == keyStr {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// KeyError is a problem with a keybinding override. Binding is empty when
+// the context itself is at fault.
+type KeyError struct {
+	Context string
+	Binding string
+	Msg     string
+}
+
+// Error formats the problem with its context
+func (e *KeyError) Error() string {
+	return e.Context + ": " + e.Msg
+}
+
+// BuildKeyMaps applies keybinding overrides (context -> binding name ->
+// keys) to the defaults. An empty key list unbinds a binding. Unknown
+// contexts and bindings are skipped and reported, as are keys an override
+// makes shared by two bindings of the same context.
+func BuildKeyMaps(overrides map[string]map[string][]string) (KeyMaps, []*KeyError) {
+	maps := DefaultKeyMaps()
+	var errs []*KeyError
+
+	var unknown []string
+	for context := range overrides {
+		if maps.Context(context) == nil {
+			unknown = append(unknown, context)
+		}
+	}
+	sort.Strings(unknown)
+	for _, context := range unknown {
+		errs = append(errs, &KeyError{Context: context, Msg: fmt.Sprintf("unknown keybinding context (use %s)",
+			strings.Join(KeyContexts, ", "))})
+	}
+
+	for _, context := range KeyContexts {
+		remaps := overrides[context]
+		if len(remaps) == 0 {
+			continue
+		}
+		km := maps.Context(context)
+		bindings := km.bindings()
+		used := make(map[string]bool)
+		for _, name := range contextBindings[context] {
+			used[name] = true
+		}
+
+		names := make([]string, 0, len(remaps))
+		for name := range remaps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		changed := make(map[string]bool)
+		for _, name := range names {
+			b, ok := bindings[name]
+			switch {
+			case !ok:
+				errs = append(errs, &KeyError{Context: context, Binding: name, Msg: fmt.Sprintf("unknown binding %q", name)})
+				continue
+			case !used[name]:
+				errs = append(errs, &KeyError{Context: context, Binding: name,
+					Msg: fmt.Sprintf("binding %q is not used in this context (use %s)",
+						name, strings.Join(contextBindings[context], ", "))})
+				continue
+			}
+			rebind(b, remaps[name])
+			changed[name] = true
+		}
+
+		for _, pair := range helpPairs {
+			if changed[pair[0]] || changed[pair[1]] {
+				first, second := bindings[pair[0]], bindings[pair[1]]
+				first.SetHelp(pairHelp(*first, *second), first.Help().Desc)
+			}
+		}
+
+		errs = append(errs, conflicts(context, bindings, changed)...)
+	}
+	return maps, errs
+}
+
+// rebind replaces a binding's keys and the key shown in its help
+func rebind(b *key.Binding, keys []string) {
+	if len(keys) == 0 {
+		b.SetEnabled(false)
+		return
+	}
+	b.SetKeys(keys...)
+	b.SetEnabled(true)
+	if b.Help().Desc == "" {
+		return
+	}
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = KeyLabel(k)
+	}
+	b.SetHelp(strings.Join(labels, "/"), b.Help().Desc)
+}
+
+// pairHelp is the help key of a binding pair such as j/k
+func pairHelp(first, second key.Binding) string {
+	var labels []string
+	for _, b := range []key.Binding{first, second} {
+		if b.Enabled() && len(b.Keys()) > 0 {
+			labels = append(labels, KeyLabel(b.Keys()[0]))
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// conflicts reports keys shared by two bindings of a context where at least
+// one of them was remapped; the defaults' own overlaps are intentional
+func conflicts(context string, bindings map[string]*key.Binding, changed map[string]bool) []*KeyError {
+	var errs []*KeyError
+	owner := make(map[string]string)
+	for _, name := range contextBindings[context] {
+		b := bindings[name]
+		if !b.Enabled() {
+			continue
+		}
+		for _, k := range b.Keys() {
+			other, taken := owner[k]
+			if !taken {
+				owner[k] = name
+				continue
+			}
+			if changed[name] || changed[other] {
+				culprit := name
+				if !changed[name] {
+					culprit = other
+				}
+				errs = append(errs, &KeyError{Context: context, Binding: culprit,
+					Msg: fmt.Sprintf("%q is bound to both %s and %s", k, other, name)})
+			}
+		}
+	}
+	return errs
+}
+
+// KeyLabel is the short form of a key used in help text
+func KeyLabel(k string) string {
+	switch {
+	case k == " ":
+		return "space"
+	case strings.HasPrefix(k, "ctrl+"):
+		return "^" + strings.TrimPrefix(k, "ctrl+")
+	}
+	return k
+}
+
+// HelpKey is the key shown for a binding in help text, or "" if the
+// binding is disabled
+func HelpKey(b key.Binding) string {
+	if !b.Enabled() {
+		return ""
+	}
+	if h := b.Help().Key; h != "" {
+		return h
+	}
+	if keys := b.Keys(); len(keys) > 0 {
+		return KeyLabel(keys[0])
+	}
+	return ""
+}
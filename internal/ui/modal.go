@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -15,13 +16,22 @@ const (
 	ModalInput ModalType = iota
 	ModalSelect
 	ModalTextarea
+	ModalPicker // select list with type-to-filter
 )
 
+// pickerVisibleRows is the number of options shown at once in a picker modal
+const pickerVisibleRows = 12
+
 // ModalOption represents an option in a select modal
 type ModalOption struct {
 	Label    string
 	Value    string
 	Shortcut string // Single key shortcut (e.g., "0", "1", "2")
+
+	// Picker-only fields
+	Group    string // heading the option is listed under (options should be sorted by group)
+	Disabled bool   // shown but cannot be chosen
+	Note     string // short explanation, e.g. why the option is disabled
 }
 
 // Modal represents a centered overlay dialog
@@ -29,6 +39,7 @@ type Modal struct {
 	Type     ModalType
 	Title    string
 	Subtitle string // e.g., issue ID
+	Help     string // overrides the default key hints when set
 
 	// For input modals
 	Input textinput.Model
@@ -39,6 +50,10 @@ type Modal struct {
 	// For select modals
 	Options  []ModalOption
 	Selected int
+
+	// For picker modals: indices into Options matching the filter input.
+	// Selected indexes into Filtered rather than Options.
+	Filtered []int
 }
 
 // NewModalInput creates a new text input modal
@@ -77,6 +92,54 @@ func NewModalSelect(title, subtitle string, options []ModalOption, currentValue
 	}
 }
 
+// NewModalPicker creates a select modal whose options can be narrowed by
+// typing. The Input field holds the filter query.
+func NewModalPicker(title, subtitle string, options []ModalOption) Modal {
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	ti.Placeholder = "type to filter"
+	ti.Focus()
+	ti.CharLimit = 100
+	ti.Width = 50
+
+	m := Modal{
+		Type:     ModalPicker,
+		Title:    title,
+		Subtitle: subtitle,
+		Input:    ti,
+		Options:  options,
+	}
+	m.ApplyFilter()
+	return m
+}
+
+// ApplyFilter recomputes the visible picker options from the filter input.
+// Every whitespace-separated word in the query must appear in the option's
+// label or value (case-insensitive).
+func (m *Modal) ApplyFilter() {
+	words := strings.Fields(strings.ToLower(m.Input.Value()))
+	m.Filtered = m.Filtered[:0]
+	for i, opt := range m.Options {
+		haystack := strings.ToLower(opt.Label + " " + opt.Value + " " + opt.Group)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(haystack, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			m.Filtered = append(m.Filtered, i)
+		}
+	}
+	if m.Selected >= len(m.Filtered) {
+		m.Selected = len(m.Filtered) - 1
+	}
+	if m.Selected < 0 {
+		m.Selected = 0
+	}
+}
+
 // NewModalTextarea creates a new multi-line text editing modal
 func NewModalTextarea(title, subtitle, value string, width, height int) Modal {
 	ta := textarea.New()
@@ -120,15 +183,22 @@ func (m Modal) TextareaValue() string {
 
 // MoveUp moves selection up in select modal
 func (m *Modal) MoveUp() {
-	if m.Type == ModalSelect && m.Selected > 0 {
+	if (m.Type == ModalSelect || m.Type == ModalPicker) && m.Selected > 0 {
 		m.Selected--
 	}
 }
 
 // MoveDown moves selection down in select modal
 func (m *Modal) MoveDown() {
-	if m.Type == ModalSelect && m.Selected < len(m.Options)-1 {
-		m.Selected++
+	switch m.Type {
+	case ModalSelect:
+		if m.Selected < len(m.Options)-1 {
+			m.Selected++
+		}
+	case ModalPicker:
+		if m.Selected < len(m.Filtered)-1 {
+			m.Selected++
+		}
 	}
 }
 
@@ -147,8 +217,29 @@ func (m *Modal) SelectByShortcut(key string) bool {
 	return false
 }
 
+// SelectedOption returns the currently selected option, if any
+func (m Modal) SelectedOption() (ModalOption, bool) {
+	switch m.Type {
+	case ModalPicker:
+		if m.Selected >= 0 && m.Selected < len(m.Filtered) {
+			return m.Options[m.Filtered[m.Selected]], true
+		}
+	case ModalSelect:
+		if m.Selected >= 0 && m.Selected < len(m.Options) {
+			return m.Options[m.Selected], true
+		}
+	}
+	return ModalOption{}, false
+}
+
 // SelectedValue returns the currently selected value
 func (m Modal) SelectedValue() string {
+	if m.Type == ModalPicker {
+		if m.Selected >= 0 && m.Selected < len(m.Filtered) {
+			return m.Options[m.Filtered[m.Selected]].Value
+		}
+		return ""
+	}
 	if m.Type == ModalSelect && m.Selected >= 0 && m.Selected < len(m.Options) {
 		return m.Options[m.Selected].Value
 	}
@@ -194,7 +285,11 @@ func (m Modal) View(width, height int) string {
 		// Text input - no extra border, modal border is enough
 		content.WriteString(m.Input.View())
 		content.WriteString("\n\n")
-		content.WriteString(helpStyle.Render("enter: save  esc: cancel"))
+		help := "enter: save  esc: cancel"
+		if m.Help != "" {
+			help = m.Help
+		}
+		content.WriteString(helpStyle.Render(help))
 
 	case ModalTextarea:
 		// Multi-line textarea
@@ -210,6 +305,74 @@ func (m Modal) View(width, height int) string {
 		content.WriteString("\n\n")
 		content.WriteString(helpStyle.Render("ctrl+s: save  esc: cancel"))
 
+	case ModalPicker:
+		content.WriteString(m.Input.View())
+		content.WriteString("\n\n")
+
+		// Window the option list around the selection
+		start := 0
+		if m.Selected >= pickerVisibleRows {
+			start = m.Selected - pickerVisibleRows + 1
+		}
+		end := start + pickerVisibleRows
+		if end > len(m.Filtered) {
+			end = len(m.Filtered)
+		}
+		if start > 0 {
+			content.WriteString(helpStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+		}
+		grouped := false
+		for _, opt := range m.Options {
+			if opt.Group != "" {
+				grouped = true
+				modalWidth = max(modalWidth, min(80, width-4))
+				break
+			}
+		}
+		labelWidth := modalWidth - 8 // border, padding and "> " marker
+		groupStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+		for i := start; i < end; i++ {
+			opt := m.Options[m.Filtered[i]]
+			if grouped && (i == start || m.Options[m.Filtered[i-1]].Group != opt.Group) {
+				content.WriteString(groupStyle.Render(Truncate(opt.Group, labelWidth+2)) + "\n")
+			}
+			label := opt.Label
+			if opt.Disabled && opt.Note != "" && i != m.Selected {
+				label += " (" + opt.Note + ")"
+			}
+			label = Truncate(label, labelWidth)
+			style := lipgloss.NewStyle().Foreground(ColorWhite)
+			if opt.Disabled {
+				style = lipgloss.NewStyle().Foreground(ColorMuted).Strikethrough(true)
+			}
+			if i == m.Selected {
+				content.WriteString("> " + style.Foreground(ColorAccent).Bold(true).Render(label))
+			} else {
+				content.WriteString("  " + style.Render(label))
+			}
+			content.WriteString("\n")
+		}
+		if end < len(m.Filtered) {
+			content.WriteString(helpStyle.Render(fmt.Sprintf("  ↓ %d more", len(m.Filtered)-end)) + "\n")
+		}
+		if len(m.Filtered) == 0 {
+			content.WriteString(helpStyle.Render("  (no matches)") + "\n")
+		}
+		// Explain the highlighted option when it has a note
+		if opt, ok := m.SelectedOption(); ok && opt.Note != "" {
+			noteStyle := lipgloss.NewStyle().Foreground(ColorWarning)
+			if opt.Disabled {
+				noteStyle = lipgloss.NewStyle().Foreground(ColorDanger)
+			}
+			content.WriteString("\n" + noteStyle.Width(modalWidth-6).Render(opt.Note) + "\n")
+		}
+		content.WriteString("\n")
+		help := "↑/↓: nav  enter: select  esc: cancel"
+		if m.Help != "" {
+			help = m.Help
+		}
+		content.WriteString(helpStyle.Render(help))
+
 	default:
 		// Vertical select options
 		for i, opt := range m.Options {
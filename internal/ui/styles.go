@@ -1,138 +1,193 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
 
-// Colors - lazygit-inspired theme
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Colors of the active theme (see ApplyTheme); the default is the
+// lazygit-inspired dark theme
 var (
-	ColorPrimary    = lipgloss.Color("2")       // Green (selected/active)
-	ColorSecondary  = lipgloss.Color("4")       // Blue (options/help keys)
-	ColorAccent     = lipgloss.Color("6")       // Cyan (search/accent)
-	ColorWarning    = lipgloss.Color("3")       // Yellow
-	ColorDanger     = lipgloss.Color("1")       // Red
-	ColorMuted      = lipgloss.Color("8")       // Bright black (gray)
-	ColorWhite      = lipgloss.Color("7")       // White
-	ColorMagenta    = lipgloss.Color("5")       // Magenta
-	ColorBorder     = lipgloss.Color("8")       // Gray border
+	ColorPrimary   lipgloss.Color // Green (selected/active)
+	ColorSecondary lipgloss.Color // Blue (options/help keys)
+	ColorAccent    lipgloss.Color // Cyan (search/accent)
+	ColorWarning   lipgloss.Color // Yellow
+	ColorDanger    lipgloss.Color // Red
+	ColorMuted     lipgloss.Color // Bright black (gray)
+	ColorWhite     lipgloss.Color // White
+	ColorMagenta   lipgloss.Color // Magenta
+	ColorBorder    lipgloss.Color // Gray border
+
+	ColorSelection     lipgloss.Color // Selected row background
+	ColorSelectionText lipgloss.Color // Selected row text
+	ColorHighlight     lipgloss.Color // Selected card/node background
+	ColorHighlightText lipgloss.Color // Selected card/node text
+	ColorBarBackground lipgloss.Color // Inline bar background
+	ColorBarText       lipgloss.Color // Inline bar text
+	ColorBarAccent     lipgloss.Color // Inline bar title and selected option
+
+	// Board column borders: Blocked, Open, Ready, In Progress, Done
+	BoardColumnColors [5]lipgloss.Color
 )
 
 // Priority colors
-var PriorityColors = map[int]lipgloss.Color{
-	0: ColorDanger,    // P0 - Critical (red)
-	1: ColorWarning,   // P1 - High (yellow)
-	2: ColorSecondary, // P2 - Medium (blue)
-	3: ColorMuted,     // P3 - Low (gray)
-	4: ColorMuted,     // P4 - Backlog (gray)
-}
+var PriorityColors map[int]lipgloss.Color
 
 // Status colors
-var StatusColors = map[string]lipgloss.Color{
-	"open":        ColorPrimary, // Green
-	"in_progress": ColorWarning, // Yellow
-	"closed":      ColorMuted,   // Gray
-}
+var StatusColors map[string]lipgloss.Color
 
 // Base styles
 var (
+	AppStyle              lipgloss.Style // App container
+	TitleStyle            lipgloss.Style // Title bar
+	PanelStyle            lipgloss.Style
+	FocusedPanelStyle     lipgloss.Style
+	PanelTitleStyle       lipgloss.Style
+	TaskItemStyle         lipgloss.Style
+	SelectedTaskStyle     lipgloss.Style
+	TaskIDStyle           lipgloss.Style
+	TaskTitleStyle        lipgloss.Style
+	SelectedRowStyle      lipgloss.Style // Highlighted row in lists
+	StatusBarStyle        lipgloss.Style
+	HelpBarStyle          lipgloss.Style // Help bar at bottom
+	HelpKeyStyle          lipgloss.Style
+	HelpDescStyle         lipgloss.Style
+	DetailLabelStyle      lipgloss.Style
+	DetailValueStyle      lipgloss.Style
+	FormLabelStyle        lipgloss.Style
+	FormInputStyle        lipgloss.Style
+	FormInputFocusedStyle lipgloss.Style
+	OverlayStyle          lipgloss.Style // Overlay/modal
+	ErrorStyle            lipgloss.Style
+	SuccessStyle          lipgloss.Style
+)
+
+func init() {
+	ApplyTheme(Themes[DefaultThemeName])
+}
+
+// buildStyles rebuilds the color maps and base styles from the current colors
+func buildStyles() {
+	PriorityColors = map[int]lipgloss.Color{
+		0: ColorDanger,    // P0 - Critical (red)
+		1: ColorWarning,   // P1 - High (yellow)
+		2: ColorSecondary, // P2 - Medium (blue)
+		3: ColorMuted,     // P3 - Low (gray)
+		4: ColorMuted,     // P4 - Backlog (gray)
+	}
+
+	StatusColors = map[string]lipgloss.Color{
+		"open":        ColorPrimary, // Green
+		"in_progress": ColorWarning, // Yellow
+		"closed":      ColorMuted,   // Gray
+	}
+
 	// App container
 	AppStyle = lipgloss.NewStyle().
-			Padding(0, 1)
+		Padding(0, 1)
 
 	// Title bar
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			Padding(0, 1)
+		Bold(true).
+		Foreground(ColorPrimary).
+		Padding(0, 1)
 
 	// Panel styles
 	PanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBorder).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder).
+		Padding(0, 1)
 
 	FocusedPanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorPrimary).
-				Bold(true).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Bold(true).
+		Padding(0, 1)
 
 	PanelTitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorWhite).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(ColorWhite).
+		MarginBottom(1)
 
 	// Task list item styles
 	TaskItemStyle = lipgloss.NewStyle().
-			PaddingLeft(2)
+		PaddingLeft(2)
 
 	SelectedTaskStyle = lipgloss.NewStyle().
-				PaddingLeft(1).
-				Foreground(ColorAccent).
-				Bold(true)
+		PaddingLeft(1).
+		Foreground(ColorAccent).
+		Bold(true)
 
 	TaskIDStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Width(12)
+		Foreground(ColorMuted).
+		Width(12)
 
 	TaskTitleStyle = lipgloss.NewStyle().
-			Foreground(ColorWhite)
+		Foreground(ColorWhite)
+
+	SelectedRowStyle = lipgloss.NewStyle().
+		Foreground(ColorSelectionText).
+		Background(ColorSelection).
+		Bold(true)
 
 	// Status bar
 	StatusBarStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Padding(0, 1).
-			MarginTop(1)
+		Foreground(ColorMuted).
+		Padding(0, 1).
+		MarginTop(1)
 
 	// Help bar at bottom
 	HelpBarStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Padding(0, 1)
+		Foreground(ColorMuted).
+		Padding(0, 1)
 
 	HelpKeyStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary).
-			Bold(true)
+		Foreground(ColorSecondary).
+		Bold(true)
 
 	HelpDescStyle = lipgloss.NewStyle().
-			Foreground(ColorWhite)
+		Foreground(ColorWhite)
 
 	// Detail view
 	DetailLabelStyle = lipgloss.NewStyle().
-				Foreground(ColorSecondary).
-				Bold(true).
-				Width(12)
+		Foreground(ColorSecondary).
+		Bold(true).
+		Width(12)
 
 	DetailValueStyle = lipgloss.NewStyle().
-				Foreground(ColorWhite)
+		Foreground(ColorWhite)
 
 	// Form styles
 	FormLabelStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary).
-			Bold(true).
-			MarginRight(1)
+		Foreground(ColorSecondary).
+		Bold(true).
+		MarginRight(1)
 
 	FormInputStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBorder).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder).
+		Padding(0, 1)
 
 	FormInputFocusedStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorPrimary).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(0, 1)
 
 	// Overlay/modal
 	OverlayStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(1, 2)
 
 	// Error/message styles
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ColorDanger).
-			Bold(true)
+		Foreground(ColorDanger).
+		Bold(true)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary)
-)
+		Foreground(ColorPrimary)
+}
 
 // PriorityStyle returns a styled priority string
 func PriorityStyle(priority int) lipgloss.Style {
@@ -153,3 +208,26 @@ func StatusStyle(status string) lipgloss.Style {
 	}
 	return lipgloss.NewStyle().Foreground(color)
 }
+
+// Truncate shortens s to fit within width cells, adding an ellipsis. A
+// width of zero or less leaves s as is.
+func Truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// ProgressBar returns a plain-text bar of the given width filled in
+// proportion to done/total
+func ProgressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultThemeName is the built-in theme used when none is configured
+const DefaultThemeName = "dark"
+
+// Theme is the palette every style in bb is built from
+type Theme struct {
+	Primary   lipgloss.Color // selected/active, open status
+	Secondary lipgloss.Color // help keys, labels
+	Accent    lipgloss.Color // search, selected text
+	Warning   lipgloss.Color
+	Danger    lipgloss.Color
+	Muted     lipgloss.Color
+	Text      lipgloss.Color
+	Magenta   lipgloss.Color
+	Border    lipgloss.Color
+
+	// Selected row in panels, timeline and calendar
+	Selection     lipgloss.Color
+	SelectionText lipgloss.Color
+
+	// Selected card on the board and focused node in the graph
+	Highlight     lipgloss.Color
+	HighlightText lipgloss.Color
+
+	// Inline edit bar at the bottom of the screen
+	BarBackground lipgloss.Color
+	BarText       lipgloss.Color
+	BarAccent     lipgloss.Color
+
+	// Board column borders
+	BoardBlocked    lipgloss.Color
+	BoardOpen       lipgloss.Color
+	BoardReady      lipgloss.Color
+	BoardInProgress lipgloss.Color
+	BoardDone       lipgloss.Color
+}
+
+// Themes are the built-in themes by name
+var Themes = map[string]Theme{
+	"dark": {
+		Primary:         "2",
+		Secondary:       "4",
+		Accent:          "6",
+		Warning:         "3",
+		Danger:          "1",
+		Muted:           "8",
+		Text:            "7",
+		Magenta:         "5",
+		Border:          "8",
+		Selection:       "#2a4a6d",
+		SelectionText:   "15",
+		Highlight:       "236",
+		HighlightText:   "15",
+		BarBackground:   "7",
+		BarText:         "0",
+		BarAccent:       "4",
+		BoardBlocked:    "1",
+		BoardOpen:       "7",
+		BoardReady:      "2",
+		BoardInProgress: "3",
+		BoardDone:       "6",
+	},
+	"light": {
+		Primary:         "#1a7f37",
+		Secondary:       "#0550ae",
+		Accent:          "#1b7c83",
+		Warning:         "#9a6700",
+		Danger:          "#cf222e",
+		Muted:           "#6e7781",
+		Text:            "#24292f",
+		Magenta:         "#8250df",
+		Border:          "#8c959f",
+		Selection:       "#ddf4ff",
+		SelectionText:   "#0a3069",
+		Highlight:       "#eaeef2",
+		HighlightText:   "#24292f",
+		BarBackground:   "#24292f",
+		BarText:         "#f6f8fa",
+		BarAccent:       "#54aeff",
+		BoardBlocked:    "#cf222e",
+		BoardOpen:       "#6e7781",
+		BoardReady:      "#1a7f37",
+		BoardInProgress: "#9a6700",
+		BoardDone:       "#1b7c83",
+	},
+	"high-contrast": {
+		Primary:         "10",
+		Secondary:       "12",
+		Accent:          "14",
+		Warning:         "11",
+		Danger:          "9",
+		Muted:           "7",
+		Text:            "15",
+		Magenta:         "13",
+		Border:          "15",
+		Selection:       "11",
+		SelectionText:   "0",
+		Highlight:       "15",
+		HighlightText:   "0",
+		BarBackground:   "15",
+		BarText:         "0",
+		BarAccent:       "4",
+		BoardBlocked:    "9",
+		BoardOpen:       "15",
+		BoardReady:      "10",
+		BoardInProgress: "11",
+		BoardDone:       "14",
+	},
+	"solarized": {
+		Primary:         "#859900",
+		Secondary:       "#268bd2",
+		Accent:          "#2aa198",
+		Warning:         "#b58900",
+		Danger:          "#dc322f",
+		Muted:           "#586e75",
+		Text:            "#839496",
+		Magenta:         "#d33682",
+		Border:          "#586e75",
+		Selection:       "#268bd2",
+		SelectionText:   "#fdf6e3",
+		Highlight:       "#073642",
+		HighlightText:   "#93a1a1",
+		BarBackground:   "#eee8d5",
+		BarText:         "#657b83",
+		BarAccent:       "#268bd2",
+		BoardBlocked:    "#dc322f",
+		BoardOpen:       "#839496",
+		BoardReady:      "#859900",
+		BoardInProgress: "#b58900",
+		BoardDone:       "#2aa198",
+	},
+}
+
+// colors maps the config names of a theme's colors to its fields
+func (t *Theme) colors() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"primary":         &t.Primary,
+		"secondary":       &t.Secondary,
+		"accent":          &t.Accent,
+		"warning":         &t.Warning,
+		"danger":          &t.Danger,
+		"muted":           &t.Muted,
+		"text":            &t.Text,
+		"magenta":         &t.Magenta,
+		"border":          &t.Border,
+		"selection":       &t.Selection,
+		"selectionText":   &t.SelectionText,
+		"highlight":       &t.Highlight,
+		"highlightText":   &t.HighlightText,
+		"barBackground":   &t.BarBackground,
+		"barText":         &t.BarText,
+		"barAccent":       &t.BarAccent,
+		"boardBlocked":    &t.BoardBlocked,
+		"boardOpen":       &t.BoardOpen,
+		"boardReady":      &t.BoardReady,
+		"boardInProgress": &t.BoardInProgress,
+		"boardDone":       &t.BoardDone,
+	}
+}
+
+// ThemeNames returns the names of the built-in themes, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeColorNames returns the color names a theme's colors can be
+// overridden by, sorted
+func ThemeColorNames() []string {
+	var t Theme
+	names := make([]string, 0, len(t.colors()))
+	for name := range t.colors() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveTheme looks up a built-in theme ("" is the default) and applies
+// color overrides given as ANSI numbers or hex values. On error the default
+// theme is returned along with it.
+func ResolveTheme(name string, overrides map[string]string) (Theme, error) {
+	if name == "" {
+		name = DefaultThemeName
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		return Themes[DefaultThemeName], fmt.Errorf("unknown theme %q (available: %s)",
+			name, strings.Join(ThemeNames(), ", "))
+	}
+
+	fields := theme.colors()
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		field, ok := fields[k]
+		if !ok {
+			return Themes[DefaultThemeName], fmt.Errorf("unknown theme color %q", k)
+		}
+		value := strings.TrimSpace(overrides[k])
+		if !validColor(value) {
+			return Themes[DefaultThemeName], fmt.Errorf("theme color %s: %q is not an ANSI color number or #rrggbb", k, value)
+		}
+		*field = lipgloss.Color(value)
+	}
+	return theme, nil
+}
+
+// validColor accepts ANSI color numbers (0-255) and #rgb/#rrggbb hex values
+func validColor(s string) bool {
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		for _, c := range strings.ToLower(hex) {
+			if !strings.ContainsRune("0123456789abcdef", c) {
+				return false
+			}
+		}
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ApplyTheme sets the package colors from a theme and rebuilds all styles.
+// Views pick up the new styles on their next render.
+func ApplyTheme(t Theme) {
+	ColorPrimary = t.Primary
+	ColorSecondary = t.Secondary
+	ColorAccent = t.Accent
+	ColorWarning = t.Warning
+	ColorDanger = t.Danger
+	ColorMuted = t.Muted
+	ColorWhite = t.Text
+	ColorMagenta = t.Magenta
+	ColorBorder = t.Border
+	ColorSelection = t.Selection
+	ColorSelectionText = t.SelectionText
+	ColorHighlight = t.Highlight
+	ColorHighlightText = t.HighlightText
+	ColorBarBackground = t.BarBackground
+	ColorBarText = t.BarText
+	ColorBarAccent = t.BarAccent
+	BoardColumnColors = [5]lipgloss.Color{
+		t.BoardBlocked, t.BoardOpen, t.BoardReady, t.BoardInProgress, t.BoardDone,
+	}
+	buildStyles()
+}
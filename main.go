@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,11 +13,13 @@ import (
 	"github.com/josebiro/bb/internal/app"
 	"github.com/josebiro/bb/internal/beads"
 	"github.com/josebiro/bb/internal/config"
+	"github.com/josebiro/bb/internal/ui"
 )
 
 func main() {
 	checkMode := flag.Bool("check", false, "Run headless validation (test bd CLI integration)")
 	configMode := flag.Bool("config", false, "Show config loading status and diagnostics")
+	freshMode := flag.Bool("fresh", false, "Start with the default UI state instead of restoring the last session")
 	flag.Parse()
 
 	// Config diagnostics mode (runs before beads check)
@@ -59,17 +62,36 @@ func main() {
 		return
 	}
 
+	// Restore the UI state of the last session in this repository
+	model := app.New()
+	repoDir := config.RepoRoot()
+	if !*freshMode {
+		session, err := config.LoadSession(repoDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring saved UI state: %v\n", err)
+		}
+		model.RestoreSession(session)
+	}
+
 	// Create and run the TUI application
 	p := tea.NewProgram(
-		app.New(),
+		model,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running bb: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Save the UI state for the next start
+	if m, ok := final.(app.Model); ok {
+		if err := config.SaveSession(repoDir, m.Session()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save UI state: %v\n", err)
+		}
+	}
 }
 
 // runCheck performs headless validation of the beads client
@@ -188,43 +210,133 @@ func showConfigStatus() {
 		fmt.Printf("  BB_CONFIG: %s\n", envValue)
 	}
 
-	// Show resolved config path
+	// Show resolved config paths
 	configPath := config.ConfigPath()
-	fmt.Printf("  Config path:      %s\n", configPath)
-
-	// Check if file exists
-	_, statErr := os.Stat(configPath)
-	fileExists := statErr == nil
-	if fileExists {
-		fmt.Println("  File exists:      yes")
+	fmt.Printf("  User config:      %s (%s)\n", configPath, existsLabel(configPath))
+	repoPath := config.RepoConfigPath()
+	if repoPath != "" {
+		fmt.Printf("  Repo config:      %s (exists)\n", repoPath)
 	} else {
-		fmt.Println("  File exists:      no")
+		fmt.Printf("  Repo config:      none (looked for %s)\n", strings.Join(config.RepoConfigFiles, ", "))
 	}
+	statePath := config.StatePath()
+	fmt.Printf("  UI state:         %s (%s)\n", statePath, existsLabel(statePath))
 
 	// Attempt to parse and show status
-	var cfg *config.Config
-	var parseErr error
-	if fileExists {
-		cfg, parseErr = config.Load()
-		if parseErr != nil {
-			fmt.Printf("  Parse status:     error (%v)\n", parseErr)
-		} else {
-			fmt.Println("  Parse status:     ok")
-		}
-	} else {
+	cfg, parseErr := config.Load()
+	problems := app.ConfigProblems(cfg)
+	switch {
+	case parseErr != nil:
+		fmt.Printf("  Parse status:     error (%v)\n", parseErr)
+	case len(cfg.Sources) == 0:
 		fmt.Println("  Parse status:     n/a (no config file)")
+	case len(problems) > 0:
+		fmt.Printf("  Parse status:     ok with %d problem(s), see below\n", len(problems))
+	default:
+		fmt.Println("  Parse status:     ok")
 	}
 
 	fmt.Println()
 
+	// Show where each setting came from
+	if cfg != nil && len(cfg.Sources) > 0 {
+		fmt.Println("Settings by source (later files override earlier ones)")
+		for _, source := range cfg.Sources {
+			var settings []string
+			for setting, origin := range cfg.Origins {
+				if origin.Path == source {
+					settings = append(settings, setting)
+				}
+			}
+			sort.Strings(settings)
+			fmt.Printf("  %s (%d)\n", source, len(settings))
+			for _, setting := range settings {
+				fmt.Printf("    %s\n", setting)
+			}
+		}
+		fmt.Println()
+	}
+
 	// Show custom commands
 	if cfg != nil && len(cfg.CustomCommands) > 0 {
 		fmt.Printf("Custom Commands (%d loaded)\n", len(cfg.CustomCommands))
 		for _, cmd := range cfg.CustomCommands {
-			fmt.Printf("  %s  %q  (%s)\n", cmd.Key, cmd.Description, cmd.Context)
+			fmt.Printf("  %s  %q  (%s, %s)\n", cmd.Key, cmd.Description, cmd.Context, cmd.Mode)
 		}
 	} else {
 		fmt.Println("Custom Commands (0 loaded)")
 		fmt.Println("  (none)")
 	}
+
+	// Show theme
+	fmt.Println()
+	var themeCfg config.ThemeConfig
+	if cfg != nil {
+		themeCfg = cfg.Theme
+	}
+	themeName := themeCfg.Name
+	if themeName == "" {
+		themeName = ui.DefaultThemeName + " (default)"
+	}
+	fmt.Println("Theme")
+	fmt.Printf("  Name:             %s\n", themeName)
+	if len(themeCfg.Colors) > 0 {
+		fmt.Printf("  Color overrides:  %d\n", len(themeCfg.Colors))
+	}
+	if _, err := ui.ResolveTheme(themeCfg.Name, themeCfg.Colors); err != nil {
+		fmt.Printf("  Status:           error (%v)\n", err)
+	} else {
+		fmt.Println("  Status:           ok")
+	}
+	fmt.Printf("  Built-in themes:  %s\n", strings.Join(ui.ThemeNames(), ", "))
+
+	// Show keybinding overrides and conflicts
+	fmt.Println()
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	overrides := 0
+	for _, bindings := range cfg.Keybindings {
+		overrides += len(bindings)
+	}
+	fmt.Printf("Keybindings (%d overridden)\n", overrides)
+	for _, context := range ui.KeyContexts {
+		names := make([]string, 0, len(cfg.Keybindings[context]))
+		for name := range cfg.Keybindings[context] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			keys := cfg.Keybindings[context][name]
+			if len(keys) == 0 {
+				fmt.Printf("  %-7s %-16s (unbound)\n", context, name)
+			} else {
+				labels := make([]string, len(keys))
+				for i, k := range keys {
+					labels[i] = ui.KeyLabel(k)
+				}
+				fmt.Printf("  %-7s %-16s %s\n", context, name, strings.Join(labels, ", "))
+			}
+		}
+	}
+
+	// Show every problem found, with its location
+	fmt.Println()
+	if len(problems) > 0 {
+		fmt.Printf("Problems (%d)\n", len(problems))
+		for _, p := range problems {
+			fmt.Printf("  %s\n", p.Error())
+		}
+	} else {
+		fmt.Println("Problems (0)")
+		fmt.Println("  (none)")
+	}
+}
+
+// existsLabel describes whether a file exists, for config diagnostics
+func existsLabel(path string) string {
+	if _, err := os.Stat(path); err != nil {
+		return "not found"
+	}
+	return "exists"
 }